@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"github.com/spf13/cobra"
+	"gomod.alauda.cn/gomod-version-lint/options"
+)
+
+func NewBaselineCmd(ctx context.Context, opts *options.RootOptions) *cobra.Command {
+
+	baselineOpts := &options.BaselineOptions{
+		BranchesOptions: options.BranchesOptions{
+			RootOptions: *opts,
+			Context:     ctx,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "baseline",
+		Args:  usageArgs(cobra.NoArgs),
+		Short: "record current violations of go module dependencies into baseline file",
+		Long: `record current violations of go module dependencies into baseline file,
+violations recorded in baseline file will not be reported by branches command with --baseline`,
+		PreRunE: func(cmd *cobra.Command, args []string) (err error) {
+			// flags of root command are parsed now
+			baselineOpts.RootOptions = *opts
+			if baselineOpts.Context, err = opts.WithLogger(ctx); err != nil {
+				return err
+			}
+			return baselineOpts.Validate()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return baselineOpts.Run()
+		},
+	}
+
+	baselineOpts.AddFlags(cmd.Flags())
+
+	return cmd
+}
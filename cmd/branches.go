@@ -15,9 +15,20 @@ func NewBranchesCmd(ctx context.Context, opts *options.RootOptions) *cobra.Comma
 
 	cmd := &cobra.Command{
 		Use:   "branches",
+		Args:  usageArgs(cobra.NoArgs),
 		Short: "output branches information for each go module dependency",
 		Long:  `output branches information for each go module dependency`,
+		PreRunE: func(cmd *cobra.Command, args []string) (err error) {
+			// flags of root command are parsed now
+			branchOpts.RootOptions = *opts
+			if branchOpts.Context, err = opts.WithLogger(ctx); err != nil {
+				return err
+			}
+			return branchOpts.Validate()
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// violations and analysis errors are not usage errors
+			cmd.SilenceUsage = true
 			return branchOpts.Run()
 		},
 	}
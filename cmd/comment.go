@@ -14,8 +14,13 @@ func NewCommentCmd(ctx context.Context, opts *options.RootOptions) *cobra.Comman
 
 	var commentCmd = &cobra.Command{
 		Use:   "comment",
+		Args:  usageArgs(cobra.NoArgs),
 		Short: "add comment on git server pull request",
 		Long:  `add comment on git server pull request according comments file, but will delete all old comments to avoid adding times by times`,
+		PreRunE: func(cmd *cobra.Command, args []string) (err error) {
+			commentOptions.Context, err = opts.WithLogger(ctx)
+			return err
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return commentOptions.Run()
 		},
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"context"
+	"github.com/spf13/cobra"
+	"gomod.alauda.cn/gomod-version-lint/options"
+)
+
+func NewGraphCmd(ctx context.Context, opts *options.RootOptions) *cobra.Command {
+
+	graphOpts := &options.GraphOptions{
+		BranchesOptions: options.BranchesOptions{
+			RootOptions: *opts,
+			Context:     ctx,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Args:  usageArgs(cobra.NoArgs),
+		Short: "render go module dependencies and their branch status as a graph",
+		Long: `render matched go module dependencies, and their internal dependencies when go.mod of them are known,
+as Graphviz DOT or Mermaid, nodes are colored by compliance and edges are labeled with the required version`,
+		PreRunE: func(cmd *cobra.Command, args []string) (err error) {
+			// flags of root command are parsed now
+			graphOpts.RootOptions = *opts
+			if graphOpts.Context, err = opts.WithLogger(ctx); err != nil {
+				return err
+			}
+			return graphOpts.Validate()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			return graphOpts.Run()
+		},
+	}
+
+	graphOpts.AddFlags(cmd.Flags())
+
+	return cmd
+}
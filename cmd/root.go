@@ -17,13 +17,34 @@ examples and usage of using your application. For example:
 Cobra is a CLI library for Go that empowers applications.
 This application is a tool to generate the needed files
 to quickly create a Cobra application.`,
+		// unknown commands are validated as args of root command, so they are usage errors
+		Args: usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
 	}
 
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return options.UsageError(err)
+	})
+
 	rootOpts := &options.RootOptions{}
-	rootCmd.PersistentFlags().BoolVar(&rootOpts.Debug, "debug", false, "enable debug log level")
+	rootOpts.AddFlags(rootCmd.PersistentFlags())
 
 	rootCmd.AddCommand(NewBranchesCmd(ctx, rootOpts))
 	rootCmd.AddCommand(NewCommentCmd(ctx, rootOpts))
+	rootCmd.AddCommand(NewBaselineCmd(ctx, rootOpts))
+	rootCmd.AddCommand(NewGraphCmd(ctx, rootOpts))
 
 	return rootCmd
 }
+
+// usageArgs wraps errors of the args validator as usage errors
+func usageArgs(validator cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validator(cmd, args); err != nil {
+			return options.UsageError(err)
+		}
+		return nil
+	}
+}
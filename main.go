@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"errors"
 	"go.uber.org/zap"
 	"gomod.alauda.cn/gomod-version-lint/cmd"
+	"gomod.alauda.cn/gomod-version-lint/options"
 	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
 	"os"
 )
@@ -20,6 +22,11 @@ func main() {
 	err := rootCmd.Execute()
 	if err != nil {
 		log.Error(err.Error())
-		os.Exit(1)
+
+		exitErr := &options.ExitError{}
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
+		os.Exit(options.ExitCodeError)
 	}
 }
@@ -0,0 +1,82 @@
+package options
+
+import (
+	"fmt"
+	flag "github.com/spf13/pflag"
+	"gomod.alauda.cn/gomod-version-lint/pkg"
+	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
+	"os"
+	"time"
+)
+
+// BaselineOptions baseline command options
+type BaselineOptions struct {
+	BranchesOptions
+
+	// File baseline file name
+	File string
+	// Expires expiry date of new baseline entries, format: 2006-01-02
+	Expires string
+}
+
+func (opts *BaselineOptions) Run() error {
+	logger := pkgctx.GetLogger(opts.Context)
+
+	if opts.Expires != "" {
+		if _, err := time.Parse("2006-01-02", opts.Expires); err != nil {
+			return fmt.Errorf("expires should be formatted as 2006-01-02, error: %s", err.Error())
+		}
+	}
+
+	var old *pkg.Baseline
+	if _, err := os.Stat(opts.File); err == nil {
+		old, err = loadBaseline(opts.File)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, modRequireAnalysis, err := opts.analysis()
+	if err != nil {
+		return err
+	}
+
+	baseline := pkg.NewBaseline(modRequireAnalysis, old, opts.Expires, pkg.Severity(opts.FailOn))
+	bts, err := baseline.Marshal()
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(opts.File, bts, 0644)
+	if err != nil {
+		logger.Errorf("write baseline file %s error: %s", opts.File, err.Error())
+		return err
+	}
+
+	// entries are hints rather than the output, so they are written to stderr like other hints of baseline
+	fmt.Fprintf(os.Stderr, "### BASELINE\n")
+	for _, entry := range baseline.Entries {
+		fmt.Fprintf(os.Stderr, "📌  %s\n", entry)
+	}
+	return nil
+}
+
+func loadBaseline(file string) (*pkg.Baseline, error) {
+	bts, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	baseline, err := pkg.ParseBaseline(bts)
+	if err != nil {
+		return nil, fmt.Errorf("parse baseline file %s error: %s", file, err.Error())
+	}
+	return baseline, nil
+}
+
+func (opts *BaselineOptions) AddFlags(flags *flag.FlagSet) {
+	opts.addAnalysisFlags(flags)
+	flags.StringVar(&opts.File, "file", ".gomod-version-lint-baseline.yaml", "baseline file that records current violations")
+	flags.StringVar(&opts.FailOn, "fail-on", "error", "record findings with this severity or higher, it should be the same as --fail-on of branches command")
+	flags.StringVar(&opts.Expires, "expires", "", "expiry date of new baseline entries, violations will be reported again after it, format: 2006-01-02")
+}
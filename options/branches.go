@@ -2,17 +2,21 @@ package options
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	flag "github.com/spf13/pflag"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
 	"gomod.alauda.cn/gomod-version-lint/pkg"
 	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
-	"gopkg.in/yaml.v3"
+	"gomod.alauda.cn/gomod-version-lint/pkg/report"
 	"io"
 	iofs "io/fs"
 	"os"
 	"path"
+	"path/filepath"
 	"strings"
+	"time"
 )
 
 // BranchesOptions branches command options
@@ -25,19 +29,165 @@ type BranchesOptions struct {
 	ModuleRegex string
 	// gomod directory
 	ModDir string
+	// Recursive analyses all go.mod files in mod dir recursively
+	Recursive bool
+	// Consistency how versions of a module required by multiple go.mod files should be consistent, none, version or branch
+	Consistency string
 	// OutputFmt output format, json or yaml
 	OutputFmt string
+	// TemplateFile text/template file for template output format
+	TemplateFile string
 	// OutputFile output file name
 	OutputFile string
 	// CommentsFile comments file name
 	CommentsFile string
-	Concurrency  int8
+	// SummaryFile file name of markdown report that used as the summary comment of pull request
+	SummaryFile string
+	// BaselineFile baseline file name, findings recorded in it will not be reported
+	BaselineFile string
+	// DenyListFile deny list file name, versions in it must never be used
+	DenyListFile string
+	// OSVDatabase directory or zip file of local OSV database
+	OSVDatabase string
+	// FailOn findings with severity equal or higher than it are violations, info, warning, error or none
+	FailOn string
+	// MaxViolations count of violations that allowed before failing
+	MaxViolations int
+	// ErrorPolicy severity for each class of analysis error, eg. timeout=warning
+	ErrorPolicy map[string]string
+	// Timeout timeout of analysis for each module
+	Timeout time.Duration
+	// PendingUntilMerged marks comments of upstream pull requests as pending until they are merged
+	PendingUntilMerged bool
+	// RequireCI requires ci checks of the pinned commit to be succeeded
+	RequireCI bool
+	// RequiredChecks names of required ci contexts
+	RequiredChecks []string
+	// VerifySignature requires the pinned commit or tag to be signed by trusted signers
+	VerifySignature bool
+	// GPGHome gpg home directory that contains trusted keyring
+	GPGHome string
+	// AllowedSignersFile allowed signers file for ssh signatures
+	AllowedSignersFile string
+	Concurrency        int8
 
 	FS      iofs.FS
 	Context context.Context
+
+	// modulePaths module paths of analysed go.mod files
+	modulePaths map[string]string
+	// analysisOptions options that modules are analysed with
+	analysisOptions pkg.AnalysisOptions
+	// denyList deny list that applied to analysed modules, it is nil if not provided
+	denyList *pkg.DenyList
+	// osvDatabase OSV database that applied to analysed modules, it is nil if not provided
+	osvDatabase *pkg.OSVDatabase
+}
+
+// Validate validates the flags of branches command
+func (opts *BranchesOptions) Validate() error {
+	if opts.FailOn != "none" {
+		if _, err := pkg.ParseSeverity(opts.FailOn); err != nil {
+			return UsageError(fmt.Errorf("invalid --fail-on: %s", err.Error()))
+		}
+	}
+	if opts.OutputFmt == "dot" || opts.OutputFmt == "mermaid" {
+		return UsageError(fmt.Errorf("--out %s is not supported, use graph command to render the dependency graph", opts.OutputFmt))
+	}
+	if opts.OutputFmt == "template" && opts.TemplateFile == "" {
+		return UsageError(fmt.Errorf("--template should be provided when --out is template"))
+	}
+	if opts.MaxViolations < 0 {
+		return UsageError(fmt.Errorf("--max-violations should not be negative"))
+	}
+	if _, err := pkg.ParseConsistencyMode(opts.Consistency); err != nil {
+		return UsageError(fmt.Errorf("invalid --consistency: %s", err.Error()))
+	}
+	if _, err := pkg.ParseErrorPolicy(opts.ErrorPolicy); err != nil {
+		return UsageError(fmt.Errorf("invalid --error-policy: %s", err.Error()))
+	}
+	return nil
 }
 
 func (opts *BranchesOptions) Run() error {
+	modFilePath, modRequireAnalysis, err := opts.analysis()
+	if err != nil {
+		return err
+	}
+
+	removable, expired := []pkg.BaselineEntry{}, []pkg.BaselineEntry{}
+	if opts.BaselineFile != "" {
+		baseline, err := loadBaseline(opts.BaselineFile)
+		if err != nil {
+			return err
+		}
+		removable, expired = baseline.Apply(modRequireAnalysis, time.Now())
+	}
+
+	err = opts.writeAnalysisResult(modRequireAnalysis)
+	if err != nil {
+		return err
+	}
+	writeBaselineResult(removable, expired)
+
+	if summaryFile := os.Getenv("GITHUB_STEP_SUMMARY"); summaryFile != "" {
+		err = opts.writeStepSummary(modRequireAnalysis, summaryFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.CommentsFile != "" {
+		err = opts.writeGitCommentsFile(modRequireAnalysis, modFilePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.SummaryFile != "" {
+		err = opts.writeSummaryFile(modRequireAnalysis)
+		if err != nil {
+			return err
+		}
+	}
+
+	return opts.checkViolations(modRequireAnalysis)
+}
+
+// checkViolations returns ExitError when there are analysis errors or violations exceed max violations,
+// analysis errors and violations are findings with severity --fail-on or higher according to the error policy
+func (opts *BranchesOptions) checkViolations(modRequireAnalysis []pkg.ModRequireAnalysis) error {
+	// analysis errors with severity error still fail when --fail-on is none
+	errorThreshold := pkg.Severity(opts.FailOn)
+	if opts.FailOn == "none" {
+		errorThreshold = pkg.SeverityError
+	}
+
+	errorCount := 0
+	for _, item := range modRequireAnalysis {
+		for _, finding := range item.Findings {
+			if finding.Type == pkg.FindingAnalysisError && finding.IsViolation(errorThreshold) {
+				errorCount++
+				break
+			}
+		}
+	}
+	if errorCount > 0 {
+		return &ExitError{Code: ExitCodeError, Err: fmt.Errorf("analysis of %d modules failed", errorCount)}
+	}
+
+	count := pkg.CountViolations(modRequireAnalysis, pkg.Severity(opts.FailOn))
+	if count > opts.MaxViolations {
+		return &ExitError{
+			Code: ExitCodeViolation,
+			Err:  fmt.Errorf("found %d violations with severity %s or higher, max violations is %d", count, opts.FailOn, opts.MaxViolations),
+		}
+	}
+	return nil
+}
+
+// analysis analyses modules in go.mod and returns go.mod file path and analysis result
+func (opts *BranchesOptions) analysis() (string, []pkg.ModRequireAnalysis, error) {
 	logger := pkgctx.GetLogger(opts.Context)
 
 	modDir := "./"
@@ -46,73 +196,259 @@ func (opts *BranchesOptions) Run() error {
 	}
 	modFilePath := path.Join(modDir, "go.mod")
 
-	fs := os.DirFS(modDir)
-	if opts.FS != nil {
-		opts.FS = fs
+	fs := opts.FS
+	if fs == nil {
+		fs = os.DirFS(modDir)
 	}
 
-	bts, err := iofs.ReadFile(fs, "go.mod")
+	consistency, err := pkg.ParseConsistencyMode(opts.Consistency)
 	if err != nil {
-		logger.Errorf("read file %s error: %s", modFilePath, err.Error())
-		return err
+		return "", nil, UsageError(fmt.Errorf("invalid --consistency: %s", err.Error()))
 	}
 
-	modFile, err := pkg.ParseModFile(modFilePath, bts)
-	if err != nil {
-		logger.Errorf("parse mod file error: %s", err.Error())
-		return err
+	modFileNames := []string{"go.mod"}
+	if opts.Recursive {
+		modFileNames, err = pkg.FindModFiles(fs)
+		if err != nil {
+			logger.Errorf("find go.mod files in %s error: %s", modDir, err.Error())
+			return "", nil, err
+		}
 	}
 
-	requredModules, err := pkg.MatchModules(opts.Context, modFile, opts.ModuleRegex)
-	if err != nil {
-		logger.Errorf("match modules by regex: %v error: %s", opts.ModuleRegex, err)
-		return err
+	opts.modulePaths = map[string]string{}
+	modFiles := []modRequires{}
+	requredModules := []modfile.Require{}
+	seen := map[module.Version]bool{}
+	for _, name := range modFileNames {
+		filePath := path.Join(modDir, name)
+		bts, err := iofs.ReadFile(fs, name)
+		if err != nil {
+			logger.Errorf("read file %s error: %s", filePath, err.Error())
+			return "", nil, err
+		}
+
+		modFile, err := pkg.ParseModFile(filePath, bts)
+		if err != nil {
+			logger.Errorf("parse mod file error: %s", err.Error())
+			return "", nil, err
+		}
+
+		requires, err := pkg.MatchModules(opts.Context, modFile, opts.ModuleRegex)
+		if err != nil {
+			logger.Errorf("match modules by regex: %v error: %s", opts.ModuleRegex, err)
+			return "", nil, err
+		}
+		modFiles = append(modFiles, modRequires{path: filePath, file: modFile, requires: requires})
+		if modFile.Module != nil {
+			opts.modulePaths[filePath] = modFile.Module.Mod.Path
+		}
+
+		for _, require := range requires {
+			if !seen[require.Mod] {
+				seen[require.Mod] = true
+				requredModules = append(requredModules, require)
+			}
+		}
 	}
 
-	modRequireAnalysis := pkg.BranchAnalysis(opts.Context, requredModules, opts.Concurrency)
-	err = opts.writeAnalysisResultV2(modRequireAnalysis)
-	if err != nil {
-		return err
+	var denyList *pkg.DenyList
+	if opts.DenyListFile != "" {
+		denyList, err = loadDenyList(opts.DenyListFile)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	var osvDatabase *pkg.OSVDatabase
+	if opts.OSVDatabase != "" {
+		osvDatabase, err = pkg.LoadOSVDatabase(opts.OSVDatabase)
+		if err != nil {
+			logger.Errorf("load osv database %s error: %s", opts.OSVDatabase, err.Error())
+			return "", nil, err
+		}
 	}
 
-	modRequireAnalysis, err = pkg.ExcludeBranches(opts.Context, modRequireAnalysis, opts.ExcludeBranchesRegex)
+	errorPolicy, err := pkg.ParseErrorPolicy(opts.ErrorPolicy)
 	if err != nil {
-		return err
+		return "", nil, UsageError(fmt.Errorf("invalid --error-policy: %s", err.Error()))
 	}
 
-	if opts.CommentsFile != "" {
-		err = opts.writeGitCommentsFile(modRequireAnalysis, modFilePath)
+	signature := pkg.SignatureOptions{
+		Verify:  opts.VerifySignature,
+		GPGHome: opts.GPGHome,
+	}
+	if signature.GPGHome == "" {
+		signature.GPGHome = os.Getenv("GNUPGHOME")
+	}
+	if home, err := os.UserHomeDir(); signature.GPGHome == "" && err == nil {
+		signature.GPGHome = filepath.Join(home, ".gnupg")
+	}
+	if opts.AllowedSignersFile != "" {
+		signature.AllowedSignersFile, err = filepath.Abs(opts.AllowedSignersFile)
 		if err != nil {
-			return err
+			return "", nil, err
 		}
 	}
 
-	return nil
+	opts.denyList = denyList
+	opts.osvDatabase = osvDatabase
+	opts.analysisOptions = pkg.AnalysisOptions{
+		Concurrency:          opts.Concurrency,
+		AllowedBranchesRegex: opts.ExcludeBranchesRegex,
+		ErrorPolicy:          errorPolicy,
+		Timeout:              opts.Timeout,
+		Token:                os.Getenv("TOKEN"),
+		PendingUntilMerged:   opts.PendingUntilMerged,
+		RequireCI:            opts.RequireCI,
+		RequiredChecks:       opts.RequiredChecks,
+		Signature:            signature,
+		GoVersion:            oldestGoVersion(modFiles),
+		Proxy:                proxyOptions(),
+	}
+	modRequireAnalysis := pkg.BranchAnalysis(opts.Context, requredModules, opts.analysisOptions)
+	modRequireAnalysis = expandAnalysis(modRequireAnalysis, modFiles)
+	pkg.ApplyConsistency(modRequireAnalysis, consistency)
+	opts.applyLists(modRequireAnalysis)
+	pkg.ApplyDirectives(modRequireAnalysis, time.Now())
+
+	return modFilePath, modRequireAnalysis, nil
+}
+
+// applyLists applies deny list and OSV database to analysed modules
+func (opts *BranchesOptions) applyLists(modRequireAnalysis []pkg.ModRequireAnalysis) {
+	if opts.denyList != nil {
+		pkg.ApplyDenyList(modRequireAnalysis, opts.denyList)
+	}
+	if opts.osvDatabase != nil {
+		pkg.ApplyOSVDatabase(modRequireAnalysis, opts.osvDatabase)
+	}
 }
 
-func (opts *BranchesOptions) writeAnalysisResultV2(modRequireAnalysis []pkg.ModRequireAnalysis) error {
-	fmt.Printf("### ANALYSIS RESULT\n")
+// writeAnalysisResultV2 writes analysis result as a table with emoji flags for human
+func (opts *BranchesOptions) writeAnalysisResultV2(modRequireAnalysis []pkg.ModRequireAnalysis, writer io.Writer) error {
 	if len(modRequireAnalysis) == 0 {
-		fmt.Printf("\n all modules %s branches matched %s", opts.ModuleRegex, opts.ExcludeBranchesRegex)
+		fmt.Fprintf(writer, "all modules %s branches matched %s\n", opts.ModuleRegex, opts.ExcludeBranchesRegex)
 		return nil
 	}
 
 	for _, item := range modRequireAnalysis {
 		matched, err := pkg.BranchMatched(opts.Context, item, opts.ExcludeBranchesRegex)
 		if err != nil {
-			fmt.Printf("🐛  %s \t error: %s", item.Mod.Path, err.Error())
+			fmt.Fprintf(writer, "🐛  %s \t error: %s\n", item.Mod.Path, err.Error())
 			continue
 		}
 		flag := "✅️"
-		if !matched {
+		detail := strings.Join(item.Branches, ",")
+		if item.Error != nil {
+			flag = "🐛"
+			detail = item.Error.Error()
+		} else if !matched {
 			flag = "⚠️ "
 		}
-		fmt.Printf("%s  %s %s\n", flag, fillSpace(item.Mod.Path+"@"+item.Mod.Version, 100), strings.Join(item.Branches, ","))
+		if flag != "✅️" && statusFindingSuppressed(item) {
+			flag = "🔕"
+		}
+		name := item.Mod.Path + "@" + item.Mod.Version
+		if opts.Recursive {
+			name = fmt.Sprintf("%s (%s:%d)", name, item.ModFile, item.Syntax.Start.Line)
+		}
+		fmt.Fprintf(writer, "%s  %s %s\n", flag, fillSpace(name, 100), detail)
+		if item.Signer != "" {
+			fmt.Fprintf(writer, "    🔏 signed by %s\n", item.Signer)
+		}
+		for _, finding := range item.Findings {
+			if finding.SuppressedBy != "" {
+				fmt.Fprintf(writer, "    🔕 %s (suppressed by %s)\n", finding.Message, finding.SuppressedBy)
+				continue
+			}
+			if isStatusFinding(finding) {
+				continue
+			}
+			fmt.Fprintf(writer, "    %s %s\n", severityIcon(finding.Severity), finding.Message)
+		}
 	}
 
 	return nil
 }
 
+func loadDenyList(file string) (*pkg.DenyList, error) {
+	bts, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	denyList, err := pkg.ParseDenyList(bts)
+	if err != nil {
+		return nil, fmt.Errorf("parse deny list file %s error: %s", file, err.Error())
+	}
+	return denyList, nil
+}
+
+// modRequires matched requires of a go.mod file
+type modRequires struct {
+	path     string
+	file     *modfile.File
+	requires []modfile.Require
+}
+
+// expandAnalysis returns analysis of each require in each go.mod file, modules required at the same version
+// by multiple go.mod files are analysed only once
+func expandAnalysis(analysis []pkg.ModRequireAnalysis, modFiles []modRequires) []pkg.ModRequireAnalysis {
+	analysed := map[module.Version]pkg.ModRequireAnalysis{}
+	for _, item := range analysis {
+		analysed[item.Mod] = item
+	}
+
+	res := []pkg.ModRequireAnalysis{}
+	for _, modFile := range modFiles {
+		for _, require := range modFile.requires {
+			item, ok := analysed[require.Mod]
+			if !ok {
+				continue
+			}
+			item.Require = require
+			item.ModFile = modFile.path
+			item.Findings = append([]pkg.Finding{}, item.Findings...)
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+func goVersion(modFile *modfile.File) string {
+	if modFile.Go == nil {
+		return ""
+	}
+	return modFile.Go.Version
+}
+
+// oldestGoVersion returns the oldest go directive of go.mod files, dependencies should be compatible with all of them
+func oldestGoVersion(modFiles []modRequires) string {
+	oldest := ""
+	for _, modFile := range modFiles {
+		version := goVersion(modFile.file)
+		if version != "" && (oldest == "" || semver.Compare("v"+version, "v"+oldest) < 0) {
+			oldest = version
+		}
+	}
+	return oldest
+}
+
+// proxyOptions returns go module proxy options from environment variables like go command
+func proxyOptions() pkg.ProxyOptions {
+	// proxy is only used when it is configured explicitly, so that paths of private modules are not sent to public proxy
+	goProxy := os.Getenv("GOPROXY")
+	goNoProxy := os.Getenv("GONOPROXY")
+	if goNoProxy == "" {
+		goNoProxy = os.Getenv("GOPRIVATE")
+	}
+
+	return pkg.ProxyOptions{
+		GoProxy:   strings.FieldsFunc(goProxy, func(r rune) bool { return r == ',' || r == '|' }),
+		GoNoProxy: goNoProxy,
+	}
+}
+
 func fillSpace(str string, width int) string {
 	left := width - len(str)
 	if left > 0 {
@@ -122,40 +458,113 @@ func fillSpace(str string, width int) string {
 }
 
 func (opts *BranchesOptions) writeAnalysisResult(modRequireAnalysis []pkg.ModRequireAnalysis) error {
-	if len(modRequireAnalysis) == 0 {
-		os.Stdout.Write([]byte(fmt.Sprintf("👍🏻 all modules %s branches matched %s", opts.ModuleRegex, opts.ExcludeBranchesRegex)))
-		return nil
-	}
-
-	writers := []io.Writer{os.Stdout}
-
+	var writer io.Writer = os.Stdout
 	if opts.OutputFile != "" {
 		f, err := os.Create(opts.OutputFile)
 		if err != nil {
 			return err
 		}
-		writers = append(writers, f)
+		defer f.Close()
+		writer = f
 	}
 
-	err := opts.Output(modRequireAnalysis, io.MultiWriter(writers...))
+	return opts.Output(modRequireAnalysis, writer)
+}
+
+// writeStepSummary appends markdown report to the job summary of GitHub Actions
+func (opts *BranchesOptions) writeStepSummary(modRequireAnalysis []pkg.ModRequireAnalysis, summaryFile string) error {
+	f, err := os.OpenFile(summaryFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		return err
 	}
+	defer f.Close()
+
+	return opts.writeMarkdownReport(modRequireAnalysis, f)
+}
+
+// writeSummaryFile writes markdown report to the summary file, it is the body of summary comment of comment command
+func (opts *BranchesOptions) writeSummaryFile(modRequireAnalysis []pkg.ModRequireAnalysis) error {
+	logger := pkgctx.GetLogger(opts.Context)
+
+	f, err := os.Create(opts.SummaryFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = opts.writeMarkdownReport(modRequireAnalysis, f)
+	if err != nil {
+		return err
+	}
+	logger.Infof("wrote summary to %s", opts.SummaryFile)
 	return nil
 }
 
+func (opts *BranchesOptions) writeMarkdownReport(modRequireAnalysis []pkg.ModRequireAnalysis, writer io.Writer) error {
+	reporter, err := report.New("markdown", opts.reportOptions())
+	if err != nil {
+		return err
+	}
+	return reporter.Report(writer, modRequireAnalysis)
+}
+
+// isStatusFinding returns true if the finding is represented by the flag of module in the table
+func isStatusFinding(finding pkg.Finding) bool {
+	return finding.Type == pkg.FindingBranchNotAllowed || finding.Type == pkg.FindingAnalysisError
+}
+
+func statusFindingSuppressed(item pkg.ModRequireAnalysis) bool {
+	for _, finding := range item.Findings {
+		if !isStatusFinding(finding) {
+			continue
+		}
+		if finding.SuppressedBy == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func severityIcon(severity pkg.Severity) string {
+	switch severity {
+	case pkg.SeverityError:
+		return "❌"
+	case pkg.SeverityWarning:
+		return "⚠️"
+	}
+	return "💡"
+}
+
+// writeBaselineResult writes expired and removable baseline entries to stderr, they are hints rather than the output
+func writeBaselineResult(removable []pkg.BaselineEntry, expired []pkg.BaselineEntry) {
+	if len(removable) == 0 && len(expired) == 0 {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "### BASELINE\n")
+	for _, entry := range expired {
+		fmt.Fprintf(os.Stderr, "⏰  %s is expired since %s\n", entry, entry.Expires)
+	}
+	for _, entry := range removable {
+		fmt.Fprintf(os.Stderr, "🧹  %s is removable, the violation is gone\n", entry)
+	}
+}
+
 func (opts *BranchesOptions) writeGitCommentsFile(modRequireAnalysis []pkg.ModRequireAnalysis, modFilePath string) error {
+	logger := pkgctx.GetLogger(opts.Context)
+
 	commentsFile, err := os.Create(opts.CommentsFile)
 	if err != nil {
 		return err
 	}
+	defer commentsFile.Close()
 
 	comments := makeGitFileComments(modRequireAnalysis, modFilePath)
-	fmt.Printf("### GIT COMMENTS\n")
-	err = comments.Marshal(io.MultiWriter(os.Stdout, commentsFile))
+	err = comments.Marshal(commentsFile)
 	if err != nil {
 		return err
 	}
+	logger.Infof("wrote %d comments to %s", len(comments), opts.CommentsFile)
 	return nil
 }
 
@@ -163,65 +572,107 @@ func makeGitFileComments(mods []pkg.ModRequireAnalysis, modFilePath string) GitF
 	comments := GitFileComments{}
 
 	for _, item := range mods {
-
-		body := fmt.Sprintf("⚠️ branch is %s for version: %s", strings.Join(item.Branches, ","), item.Mod.Version)
-		if strings.Join(item.Branches, ",") == "" {
-			body = "not found any branch for version: " + item.Mod.Version
+		for _, finding := range item.Findings {
+			// pending findings are still commented so that they are resolved after upstream pull request merges
+			if finding.SuppressedBy != "" && finding.SuppressedBy != pkg.PendingSuppression {
+				continue
+			}
+
+			body := fmt.Sprintf("%s %s for version: %s", severityIcon(finding.Severity), finding.Message, item.Mod.Version)
+			if finding.Type == pkg.FindingBranchNotAllowed {
+				body = fmt.Sprintf("⚠️ %s for version: %s", finding.Message, item.Mod.Version)
+			}
+			if finding.SuppressedBy == pkg.PendingSuppression {
+				body = fmt.Sprintf("⏳ %s for version: %s, pending until upstream pull request merges", finding.Message, item.Mod.Version)
+			}
+			if finding.SuggestedVersion != "" {
+				body = body + ", suggested version: " + finding.SuggestedVersion
+			}
+
+			comments = append(comments, GitFileComment{
+				FilePath: itemModFile(item, modFilePath),
+				Line:     item.Syntax.Start.Line,
+				Comment:  body,
+			})
 		}
-		comments = append(comments, GitFileComment{
-			FilePath: modFilePath,
-			Line:     item.Syntax.Start.Line,
-			Comment:  body,
-		})
 	}
 	return comments
 }
 
-func (opts *BranchesOptions) Output(requires []pkg.ModRequireAnalysis, writer io.Writer) error {
-	outputFmt := "json"
-	if opts.OutputFmt != "" {
-		outputFmt = opts.OutputFmt
+// itemModFile returns go.mod file that requires the module, or the default one if it is unknown
+func itemModFile(item pkg.ModRequireAnalysis, modFilePath string) string {
+	if item.ModFile != "" {
+		return item.ModFile
 	}
+	return modFilePath
+}
 
-	if outputFmt == "json" {
-		bts, err := json.MarshalIndent(requires, "", "  ")
-		if err != nil {
-			return err
-		}
+func (opts *BranchesOptions) Output(requires []pkg.ModRequireAnalysis, writer io.Writer) error {
+	outputFmt := opts.outputFormat()
 
-		writer.Write(bts)
-		return nil
+	if outputFmt == "table" {
+		return opts.writeAnalysisResultV2(requires, writer)
 	}
 
-	if outputFmt == "yaml" {
-		bts, err := yaml.Marshal(requires)
-		if err != nil {
-			return err
-		}
-
-		writer.Write(bts)
-		return nil
+	reporter, err := report.New(outputFmt, opts.reportOptions())
+	if err != nil {
+		return err
 	}
+	return reporter.Report(writer, requires)
+}
 
-	if outputFmt == "table" {
-		for _, item := range requires {
-			writer.Write([]byte(item.Mod.Path + "|"))
-			writer.Write([]byte(item.Mod.Version + "|" + strings.Join(item.Branches, ",")))
-			writer.Write([]byte("|" + fmt.Sprint(item.Syntax.End.Line)))
-			writer.Write([]byte("\n"))
-		}
-		return nil
+// outputFormat returns the output format, annotations of GitHub Actions are chosen automatically when running in it
+func (opts *BranchesOptions) outputFormat() string {
+	if opts.OutputFmt != "" {
+		return opts.OutputFmt
+	}
+	if os.Getenv("GITHUB_ACTIONS") == "true" {
+		return "github"
 	}
+	return "table"
+}
 
-	return fmt.Errorf("unknown output format: %s", opts.OutputFmt)
+func (opts *BranchesOptions) reportOptions() report.Options {
+	return report.Options{
+		ModFile:     path.Join(opts.ModDir, "go.mod"),
+		FailOn:      opts.FailOn,
+		Template:    opts.TemplateFile,
+		ModuleRegex: opts.ModuleRegex,
+		ModulePaths: opts.modulePaths,
+	}
 }
 
 func (opts *BranchesOptions) AddFlags(flags *flag.FlagSet) {
+	opts.addAnalysisFlags(flags)
+	flags.StringVarP(&opts.OutputFmt, "out", "o", "", "output format, one of table, json, yaml, sarif, junit, codequality, checkstyle, markdown, github, template and html, "+
+		"default is github when running in GitHub Actions, otherwise table")
+	flags.StringVar(&opts.TemplateFile, "template", "", "text/template file that renders the result when --out is template")
+	flags.StringVar(&opts.OutputFile, "out-file", "", "file that the output is written to instead of stdout")
+	flags.StringVar(&opts.CommentsFile, "comments-file", ".git-comments", "comments file")
+	flags.StringVar(&opts.SummaryFile, "summary-file", "", "file to write markdown report to, it could be posted as the summary comment of pull request by comment command")
+	flags.StringVar(&opts.BaselineFile, "baseline", "", "baseline file, violations recorded in it will not be reported, see baseline command")
+	flags.StringVar(&opts.FailOn, "fail-on", "error", "exit with code 1 when findings with this severity or higher are found, one of info, warning, error and none")
+	flags.IntVar(&opts.MaxViolations, "max-violations", 0, "count of violations that allowed before exiting with code 1")
+}
+
+func (opts *BranchesOptions) addAnalysisFlags(flags *flag.FlagSet) {
 	flags.StringVar(&opts.ModuleRegex, "module", "github.com/example/.*", "modules that you want to print branches, it supports using regex")
 	flags.StringVar(&opts.ExcludeBranchesRegex, "branches-exclude", "(^main$|^release-.*$)", "branch of modules that you want to exclude, it supports usiing regex")
 	flags.StringVarP(&opts.ModDir, "mod-dir", "d", "./", "gomod file directory")
-	flags.StringVarP(&opts.OutputFmt, "out", "o", "table", "gomod file path")
-	flags.StringVar(&opts.OutputFile, "out-file", "table", "gomod file path")
-	flags.StringVar(&opts.CommentsFile, "comments-file", ".git-comments", "comments file")
+	flags.BoolVarP(&opts.Recursive, "recursive", "r", false, "analyse all go.mod files in mod dir recursively, modules required at the same version are analysed only once")
+	flags.StringVar(&opts.Consistency, "consistency", "version", "how versions of a module required by multiple go.mod files should be consistent when --recursive, "+
+		"one of version, branch and none, branch means versions could be different but should be from the same branch")
 	flags.Int8Var(&opts.Concurrency, "concurrency", 5, "concurrency count for analysis modules")
+	flags.StringVar(&opts.OSVDatabase, "osv-db", "", "directory or zip file of local OSV database, eg. Go/all.zip of osv.dev, required versions will be matched offline")
+	flags.StringVar(&opts.DenyListFile, "deny-list", "", "deny list file in yaml or json, versions or commits of modules in it must never be used")
+	flags.StringToStringVar(&opts.ErrorPolicy, "error-policy", map[string]string{}, "severity for each class of analysis error, "+
+		"eg. timeout=warning,auth-denied=ignore, severity could be info, warning, error or ignore, classes are "+
+		"repo-not-found, auth-denied, commit-not-found, commit-unreachable, timeout, unsupported-vcs and unknown")
+	flags.DurationVar(&opts.Timeout, "timeout", 5*time.Minute, "timeout of analysis for each module")
+	flags.BoolVar(&opts.RequireCI, "require-ci", false, "require ci checks of the pinned commit of each module to be succeeded, token of git server api is read from env TOKEN")
+	flags.StringSliceVar(&opts.RequiredChecks, "required-checks", []string{}, "names of ci contexts that are required when --require-ci, all reported contexts are required if it is empty")
+	flags.BoolVar(&opts.VerifySignature, "verify-signature", false, "require the pinned commit or annotated tag of each module to be signed by trusted gpg or ssh keys")
+	flags.StringVar(&opts.GPGHome, "gpg-home", "", "gpg home directory that contains trusted keyring, default is $GNUPGHOME or ~/.gnupg")
+	flags.StringVar(&opts.AllowedSignersFile, "allowed-signers", "", "allowed signers file of trusted ssh keys, see gpg.ssh.allowedSignersFile of git")
+	flags.BoolVar(&opts.PendingUntilMerged, "pending-until-merged", false, "mark comments of feature branch dependencies as pending until their upstream pull requests are merged")
 }
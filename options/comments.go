@@ -20,6 +20,8 @@ type CommentOptions struct {
 	Repository string
 	PrID       int
 	CommitID   string
+	// SummaryFile markdown file that posted as the summary comment of pull request, it is optional
+	SummaryFile string
 
 	Context context.Context
 }
@@ -63,8 +65,18 @@ func (opts *CommentOptions) Run() error {
 		CommitID:  opts.CommitID,
 		Comments:  comments.ToReviewComments(),
 	})
+	if err != nil || opts.SummaryFile == "" {
+		return err
+	}
 
-	return err
+	summary, err := os.ReadFile(opts.SummaryFile)
+	if err != nil {
+		return err
+	}
+	return client.RefreshSummaryComment(opts.Context, repo, opts.PrID, pkgscm.RefreshSummaryCommentOptions{
+		CommentBy: "gomod-version-lint-summary",
+		Body:      string(summary),
+	})
 }
 
 func loadCommentFromFile(ctx context.Context, file string) (*GitFileComments, error) {
@@ -85,6 +97,7 @@ func loadCommentFromFile(ctx context.Context, file string) (*GitFileComments, er
 func (opts *CommentOptions) AddFlags(flags *flag.FlagSet) {
 	flags.StringVar(&opts.Repository, "repo", "https://gitub.com/example/example", "repository address")
 	flags.StringVar(&opts.File, "file", "./comments", "comments file, the file format of each line should be file-path|line-number|comment-body")
+	flags.StringVar(&opts.SummaryFile, "summary-file", "", "markdown file that posted as the summary comment of pull request, eg. --summary-file of branches command")
 	flags.StringVar(&opts.ServerType, "server-type", "", "git server type, eg. github gitlab, it is optional, "+
 		"if you do not provide it, it will set to gitlab when repository contains 'gitlab'")
 	flags.StringVar(&opts.CommitID, "commit-id", "", "current commit id of branch")
@@ -0,0 +1,29 @@
+package options
+
+const (
+	// ExitCodeViolation policy violations are found
+	ExitCodeViolation = 1
+	// ExitCodeUsage command usage is wrong, eg. unknown flag or invalid flag value
+	ExitCodeUsage = 2
+	// ExitCodeError analysis error or other errors, eg. clone repository failed
+	ExitCodeError = 3
+)
+
+// ExitError error with the exit code of process
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// UsageError wraps err as usage error
+func UsageError(err error) error {
+	return &ExitError{Code: ExitCodeUsage, Err: err}
+}
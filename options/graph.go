@@ -0,0 +1,105 @@
+package options
+
+import (
+	"fmt"
+	flag "github.com/spf13/pflag"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"gomod.alauda.cn/gomod-version-lint/pkg"
+	"gomod.alauda.cn/gomod-version-lint/pkg/report"
+	"io"
+	"os"
+	"regexp"
+)
+
+// GraphOptions graph command options
+type GraphOptions struct {
+	BranchesOptions
+
+	// Format graph format, dot or mermaid
+	Format string
+}
+
+// Validate validates the flags of graph command
+func (opts *GraphOptions) Validate() error {
+	if err := opts.BranchesOptions.Validate(); err != nil {
+		return err
+	}
+	if opts.Format != "dot" && opts.Format != "mermaid" {
+		return UsageError(fmt.Errorf("invalid --format %q, it should be dot or mermaid", opts.Format))
+	}
+	return nil
+}
+
+func (opts *GraphOptions) Run() error {
+	_, modRequireAnalysis, err := opts.analysis()
+	if err != nil {
+		return err
+	}
+	modRequireAnalysis, err = opts.transitiveAnalysis(modRequireAnalysis)
+	if err != nil {
+		return err
+	}
+
+	reporter, err := report.New(opts.Format, opts.reportOptions())
+	if err != nil {
+		return err
+	}
+
+	var writer io.Writer = os.Stdout
+	if opts.OutputFile != "" {
+		f, err := os.Create(opts.OutputFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		writer = f
+	}
+	return reporter.Report(writer, modRequireAnalysis)
+}
+
+// transitiveAnalysis analyses internal requires of analysed modules level by level until all of them are analysed,
+// so the graph could show the whole chain of internal modules that drags in a version. visited versions are not analysed again
+func (opts *GraphOptions) transitiveAnalysis(mods []pkg.ModRequireAnalysis) ([]pkg.ModRequireAnalysis, error) {
+	internal, err := regexp.Compile(pkg.AnchorRegex(opts.ModuleRegex))
+	if err != nil {
+		return nil, err
+	}
+
+	visited := map[module.Version]bool{}
+	for _, item := range mods {
+		visited[item.Mod] = true
+	}
+
+	level := mods
+	for len(level) > 0 {
+		requires := []modfile.Require{}
+		for _, item := range level {
+			for _, require := range item.Requires {
+				if visited[require] || !internal.MatchString(require.Path) {
+					continue
+				}
+				visited[require] = true
+				requires = append(requires, modfile.Require{Mod: require})
+			}
+		}
+		if len(requires) == 0 {
+			break
+		}
+
+		level = pkg.BranchAnalysis(opts.Context, requires, opts.analysisOptions)
+		for i := range level {
+			level[i].Transitive = true
+		}
+		opts.applyLists(level)
+		mods = append(mods, level...)
+	}
+	return mods, nil
+}
+
+func (opts *GraphOptions) AddFlags(flags *flag.FlagSet) {
+	opts.addAnalysisFlags(flags)
+	flags.StringVarP(&opts.Format, "format", "f", "dot", "graph format, dot or mermaid")
+	flags.StringVar(&opts.OutputFile, "out-file", "", "file that the graph is written to instead of stdout")
+	flags.StringVar(&opts.FailOn, "fail-on", "error", "findings with this severity or higher are rendered as violations, one of info, warning, error and none")
+}
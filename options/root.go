@@ -1,5 +1,49 @@
 package options
 
+import (
+	"context"
+	"fmt"
+	flag "github.com/spf13/pflag"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
+)
+
 type RootOptions struct {
+	// Debug enables debug log level, it is the same as --log-level debug
 	Debug bool
+	// LogLevel level of logs, one of debug, info, warn and error
+	LogLevel string
+	// LogFile file that logs are written to, logs are written to stderr if it is empty
+	LogFile string
+}
+
+// WithLogger returns context with the logger configured by log flags, it should be called after flags are parsed.
+// logs are never written to stdout, so stdout only contains the output of command
+func (opts *RootOptions) WithLogger(ctx context.Context) (context.Context, error) {
+	level, err := zapcore.ParseLevel(opts.LogLevel)
+	if err != nil {
+		return nil, UsageError(fmt.Errorf("invalid --log-level: %s", err.Error()))
+	}
+	if opts.Debug {
+		level = zapcore.DebugLevel
+	}
+
+	config := zap.NewProductionConfig()
+	config.Level = zap.NewAtomicLevelAt(level)
+	if opts.LogFile != "" {
+		config.OutputPaths = []string{opts.LogFile}
+	}
+
+	logger, err := config.Build()
+	if err != nil {
+		return nil, err
+	}
+	return pkgctx.WithLogger(ctx, logger.Sugar()), nil
+}
+
+func (opts *RootOptions) AddFlags(flags *flag.FlagSet) {
+	flags.BoolVar(&opts.Debug, "debug", false, "enable debug log level, git output is logged in debug level")
+	flags.StringVar(&opts.LogLevel, "log-level", "info", "log level, one of debug, info, warn and error")
+	flags.StringVar(&opts.LogFile, "log-file", "", "file that logs are written to, default is stderr")
 }
@@ -0,0 +1,150 @@
+package pkg
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"time"
+)
+
+// BaselineSuppression value of Finding.SuppressedBy when the finding is suppressed by baseline
+const BaselineSuppression = "baseline"
+
+// baselineDateLayout layout of the expiry date in baseline entry
+const baselineDateLayout = "2006-01-02"
+
+// Baseline records known violations, findings in the baseline will not be reported again
+type Baseline struct {
+	Entries []BaselineEntry `json:"entries" yaml:"entries"`
+}
+
+// BaselineEntry a known violation of a module version
+type BaselineEntry struct {
+	Module  string      `json:"module" yaml:"module"`
+	Version string      `json:"version" yaml:"version"`
+	Reason  FindingType `json:"reason" yaml:"reason"`
+	// Expires the last date that entry suppresses the violation, format: 2006-01-02, it is optional
+	Expires string `json:"expires,omitempty" yaml:"expires,omitempty"`
+}
+
+func (entry BaselineEntry) String() string {
+	return fmt.Sprintf("%s@%s %s", entry.Module, entry.Version, entry.Reason)
+}
+
+// Expired returns true if the entry is expired at now, the entry is still valid on the expires date
+func (entry BaselineEntry) Expired(now time.Time) (bool, error) {
+	if entry.Expires == "" {
+		return false, nil
+	}
+
+	expired, err := dateExpired(entry.Expires, now)
+	if err != nil {
+		return false, fmt.Errorf("expires '%s' of baseline entry %s error: %s", entry.Expires, entry, err.Error())
+	}
+	return expired, nil
+}
+
+// dateExpired returns true if now is after the whole day of date in the location of now, date is inclusive
+func dateExpired(date string, now time.Time) (bool, error) {
+	day, err := time.ParseInLocation(baselineDateLayout, date, now.Location())
+	if err != nil {
+		return false, err
+	}
+	return !now.Before(day.AddDate(0, 0, 1)), nil
+}
+
+func (entry BaselineEntry) matches(require ModRequireAnalysis, finding Finding) bool {
+	return entry.Module == require.Mod.Path && entry.Version == require.Mod.Version && entry.Reason == finding.Type
+}
+
+// ParseBaseline parses baseline file content
+func ParseBaseline(bts []byte) (*Baseline, error) {
+	baseline := &Baseline{}
+	err := yaml.Unmarshal(bts, baseline)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, entry := range baseline.Entries {
+		if _, err := entry.Expired(time.Now()); err != nil {
+			return nil, err
+		}
+		if entry.Reason == FindingBranchNotFound {
+			baseline.Entries[i].Reason = FindingAnalysisError
+		}
+	}
+	return baseline, nil
+}
+
+// Marshal marshals baseline to yaml
+func (baseline *Baseline) Marshal() ([]byte, error) {
+	return yaml.Marshal(baseline)
+}
+
+// NewBaseline records findings of modules that are violations under threshold as baseline entries,
+// expiry date of the entries that already exist in old baseline will be kept
+func NewBaseline(mods []ModRequireAnalysis, old *Baseline, expires string, threshold Severity) *Baseline {
+	baseline := &Baseline{Entries: []BaselineEntry{}}
+
+	for _, item := range mods {
+		for _, finding := range item.Findings {
+			if !finding.IsViolation(threshold) || !finding.Type.Suppressible() {
+				continue
+			}
+
+			entry := BaselineEntry{
+				Module:  item.Mod.Path,
+				Version: item.Mod.Version,
+				Reason:  finding.Type,
+				Expires: expires,
+			}
+
+			if old != nil {
+				for _, oldEntry := range old.Entries {
+					if oldEntry.matches(item, finding) {
+						entry.Expires = oldEntry.Expires
+						break
+					}
+				}
+			}
+
+			baseline.Entries = append(baseline.Entries, entry)
+		}
+	}
+
+	return baseline
+}
+
+// Apply suppresses findings of modules that recorded in baseline and not expired,
+// it returns entries which match nothing as removable, and entries which are expired
+func (baseline *Baseline) Apply(mods []ModRequireAnalysis, now time.Time) (removable []BaselineEntry, expired []BaselineEntry) {
+	removable = []BaselineEntry{}
+	expired = []BaselineEntry{}
+
+	for _, entry := range baseline.Entries {
+		// expiry is validated when parsing baseline
+		isExpired, _ := entry.Expired(now)
+
+		found := false
+		for i := range mods {
+			for j := range mods[i].Findings {
+				finding := &mods[i].Findings[j]
+				if !entry.matches(mods[i], *finding) {
+					continue
+				}
+
+				found = true
+				if !isExpired && finding.SuppressedBy == "" && finding.Type.Suppressible() {
+					finding.SuppressedBy = BaselineSuppression
+				}
+			}
+		}
+
+		if !found {
+			removable = append(removable, entry)
+		} else if isExpired {
+			expired = append(expired, entry)
+		}
+	}
+
+	return removable, expired
+}
@@ -0,0 +1,141 @@
+package pkg
+
+import (
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"testing"
+	"time"
+)
+
+var baselineString = `
+entries:
+  - module: git.example.com/demo/demo
+    version: v1.0.0-20201130134442-10cb98267c6c
+    reason: branch-not-allowed
+  - module: git.example.com/demo/expired
+    version: v1.0.0
+    reason: branch-not-allowed
+    expires: "2023-01-01"
+  - module: git.example.com/demo/gone
+    version: v1.0.0
+    reason: branch-not-found
+`
+
+func TestBaselineApply(t *testing.T) {
+	baseline, err := ParseBaseline([]byte(baselineString))
+	if err != nil {
+		t.Errorf("should parse baseline correctly, but error: %s", err.Error())
+		return
+	}
+
+	mods := []ModRequireAnalysis{
+		{
+			Require: modfile.Require{
+				Mod: module.Version{Path: "git.example.com/demo/demo", Version: "v1.0.0-20201130134442-10cb98267c6c"},
+			},
+			Findings: []Finding{{Type: FindingBranchNotAllowed}},
+		},
+		{
+			Require: modfile.Require{
+				Mod: module.Version{Path: "git.example.com/demo/expired", Version: "v1.0.0"},
+			},
+			Findings: []Finding{{Type: FindingBranchNotAllowed}},
+		},
+		{
+			Require: modfile.Require{
+				Mod: module.Version{Path: "git.example.com/demo/new", Version: "v1.0.0"},
+			},
+			Findings: []Finding{{Type: FindingBranchNotFound}},
+		},
+	}
+
+	removable, expired := baseline.Apply(mods, time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local))
+
+	if mods[0].Findings[0].SuppressedBy != BaselineSuppression {
+		t.Errorf("finding recorded in baseline should be suppressed, but: %#v", mods[0].Findings[0])
+	}
+	if mods[1].Findings[0].SuppressedBy != "" || mods[2].Findings[0].SuppressedBy != "" {
+		t.Errorf("expired or new finding should not be suppressed, but: %#v %#v", mods[1].Findings[0], mods[2].Findings[0])
+	}
+	if len(removable) != 1 || removable[0].Module != "git.example.com/demo/gone" || removable[0].Reason != FindingAnalysisError {
+		t.Errorf("removable entries should be git.example.com/demo/gone with migrated reason, but: %#v", removable)
+	}
+	if len(expired) != 1 || expired[0].Module != "git.example.com/demo/expired" {
+		t.Errorf("expired entries should be git.example.com/demo/expired, but: %#v", expired)
+	}
+}
+
+func TestNewBaseline(t *testing.T) {
+	old := &Baseline{
+		Entries: []BaselineEntry{
+			{Module: "git.example.com/demo/demo", Version: "v1.0.0", Reason: FindingBranchNotAllowed, Expires: "2023-01-01"},
+		},
+	}
+	mods := []ModRequireAnalysis{
+		{
+			Require:  modfile.Require{Mod: module.Version{Path: "git.example.com/demo/demo", Version: "v1.0.0"}},
+			Findings: []Finding{{Type: FindingBranchNotAllowed, Severity: SeverityError}},
+		},
+		{
+			Require:  modfile.Require{Mod: module.Version{Path: "git.example.com/demo/new", Version: "v1.0.0"}},
+			Findings: []Finding{{Type: FindingBranchNotFound, Severity: SeverityError}, {Type: FindingNewerRelease, Severity: SeverityInfo}},
+		},
+	}
+
+	// info findings are not violations under --fail-on error, so they are not recorded
+	baseline := NewBaseline(mods, old, "2024-01-01", SeverityError)
+	if len(baseline.Entries) != 2 {
+		t.Errorf("baseline entries length should be 2, but got: %d", len(baseline.Entries))
+		return
+	}
+	if baseline.Entries[0].Expires != "2023-01-01" || baseline.Entries[1].Expires != "2024-01-01" {
+		t.Errorf("expires of old entry should be kept and new entry should use provided one, but: %#v", baseline.Entries)
+	}
+}
+
+func TestBaselineApplyUnsuppressible(t *testing.T) {
+	baseline := &Baseline{
+		Entries: []BaselineEntry{
+			{Module: "git.example.com/demo/demo", Version: "v1.0.0", Reason: FindingDenied},
+		},
+	}
+	mods := []ModRequireAnalysis{
+		{
+			Require:  modfile.Require{Mod: module.Version{Path: "git.example.com/demo/demo", Version: "v1.0.0"}},
+			Findings: []Finding{{Type: FindingDenied, Severity: SeverityError}},
+		},
+	}
+
+	baseline.Apply(mods, time.Now())
+	if mods[0].Findings[0].SuppressedBy != "" {
+		t.Errorf("denied finding should not be suppressed by baseline, but: %#v", mods[0].Findings[0])
+	}
+	if entries := NewBaseline(mods, nil, "", SeverityError).Entries; len(entries) != 0 {
+		t.Errorf("denied finding should not be recorded in baseline, but: %#v", entries)
+	}
+}
+
+func TestBaselineEntryExpired(t *testing.T) {
+	entry := BaselineEntry{Module: "git.example.com/demo/demo", Version: "v1.0.0", Reason: FindingBranchNotAllowed, Expires: "2026-12-01"}
+
+	cases := []struct {
+		now      time.Time
+		expected bool
+	}{
+		{now: time.Date(2026, 11, 30, 23, 59, 59, 0, time.Local), expected: false},
+		{now: time.Date(2026, 12, 1, 0, 0, 0, 0, time.Local), expected: false},
+		{now: time.Date(2026, 12, 1, 23, 59, 59, 0, time.Local), expected: false},
+		{now: time.Date(2026, 12, 2, 0, 0, 0, 0, time.Local), expected: true},
+	}
+
+	for _, item := range cases {
+		expired, err := entry.Expired(item.now)
+		if err != nil {
+			t.Errorf("should check expires correctly, but error: %s", err.Error())
+			continue
+		}
+		if expired != item.expected {
+			t.Errorf("entry expires on %s should be expired %v at %s, but: %v", entry.Expires, item.expected, item.now, expired)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
+	pkgscm "gomod.alauda.cn/gomod-version-lint/pkg/scm"
+	"strings"
+	"sync"
+)
+
+// commitStatusCache caches ci statuses of commits, key is repository and sha
+type commitStatusCache struct {
+	lock    sync.Mutex
+	entries map[string]*commitStatusEntry
+	// list lists ci statuses of the commit from git server
+	list func(ctx context.Context, modulePath string, sha string, token string) ([]pkgscm.CommitStatus, error)
+}
+
+// commitStatusEntry ci statuses of a commit, its lock is held while listing them,
+// so that the same commit is listed once and different commits are listed concurrently
+type commitStatusEntry struct {
+	lock     sync.Mutex
+	listed   bool
+	statuses []pkgscm.CommitStatus
+}
+
+func newCommitStatusCache() *commitStatusCache {
+	return &commitStatusCache{entries: map[string]*commitStatusEntry{}, list: listCommitStatuses}
+}
+
+func (cache *commitStatusCache) get(ctx context.Context, modulePath string, sha string, token string) ([]pkgscm.CommitStatus, error) {
+	host, _, repoPath := scmRepository(modulePath)
+	key := host + "/" + repoPath + "@" + sha
+
+	cache.lock.Lock()
+	entry, ok := cache.entries[key]
+	if !ok {
+		entry = &commitStatusEntry{}
+		cache.entries[key] = entry
+	}
+	cache.lock.Unlock()
+
+	entry.lock.Lock()
+	defer entry.lock.Unlock()
+
+	if entry.listed {
+		return entry.statuses, nil
+	}
+
+	// errors are not cached, the commit will be listed again by next module
+	statuses, err := cache.list(ctx, modulePath, sha, token)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.statuses = statuses
+	entry.listed = true
+	return statuses, nil
+}
+
+// listCommitStatuses lists ci statuses of the commit from the git server of module
+func listCommitStatuses(ctx context.Context, modulePath string, sha string, token string) ([]pkgscm.CommitStatus, error) {
+	client, repoPath, err := newScmClient(ctx, modulePath, token)
+	if err != nil {
+		return nil, err
+	}
+	return client.ListCommitStatuses(ctx, repoPath, sha)
+}
+
+// ciFindings checks whether required ci contexts succeeded on the commit of rev,
+// all reported contexts are required if opts.RequiredChecks is empty
+func ciFindings(ctx context.Context, dir string, require ModRequireAnalysis, rev string, opts AnalysisOptions) ([]pkgscm.CommitStatus, []Finding) {
+	logger := pkgctx.GetLogger(ctx).With("module", require.Mod.Path, "rev", rev)
+
+	if !opts.RequireCI || opts.statusCache == nil {
+		return nil, nil
+	}
+
+	if require.Commit == "" {
+		return nil, []Finding{
+			{
+				Type:     FindingCINotPassed,
+				Severity: SeverityError,
+				Message:  "commit could not be resolved, ci statuses are unknown",
+			},
+		}
+	}
+
+	statuses, err := opts.statusCache.get(ctx, require.Mod.Path, require.Commit, opts.Token)
+	if err != nil {
+		logger.Errorw("list commit statuses error", "err", err)
+		return nil, []Finding{
+			{
+				Type:     FindingCINotPassed,
+				Severity: SeverityError,
+				Message:  "could not get ci statuses of commit: " + err.Error(),
+			},
+		}
+	}
+
+	problems := unpassedChecks(statuses, opts.RequiredChecks)
+	if len(problems) == 0 {
+		return statuses, nil
+	}
+
+	return statuses, []Finding{
+		{
+			Type:     FindingCINotPassed,
+			Severity: SeverityError,
+			Message:  "required ci checks not passed: " + strings.Join(problems, ", "),
+		},
+	}
+}
+
+// unpassedChecks returns required checks that are not succeeded, formatted as "<context> (<state>)",
+// all contexts in statuses are required if required is empty, and there should be one context at least
+func unpassedChecks(statuses []pkgscm.CommitStatus, required []string) []string {
+	if len(required) == 0 {
+		if len(statuses) == 0 {
+			return []string{"any (missing)"}
+		}
+		for _, item := range statuses {
+			required = append(required, item.Context)
+		}
+	}
+
+	problems := []string{}
+	checked := map[string]bool{}
+	for _, name := range required {
+		if checked[name] {
+			continue
+		}
+		checked[name] = true
+
+		state := "missing"
+		for _, item := range statuses {
+			if item.Context != name {
+				continue
+			}
+			// the context may be reported several times, any success is enough
+			state = item.State
+			if state == pkgscm.CommitStatusSuccess {
+				break
+			}
+		}
+		if state != pkgscm.CommitStatusSuccess {
+			problems = append(problems, fmt.Sprintf("%s (%s)", name, state))
+		}
+	}
+	return problems
+}
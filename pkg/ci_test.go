@@ -0,0 +1,95 @@
+package pkg
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
+	pkgscm "gomod.alauda.cn/gomod-version-lint/pkg/scm"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestUnpassedChecks(t *testing.T) {
+	statuses := []pkgscm.CommitStatus{
+		{Context: "build", State: pkgscm.CommitStatusFailure},
+		{Context: "build", State: pkgscm.CommitStatusSuccess},
+		{Context: "lint", State: pkgscm.CommitStatusPending},
+		{Context: "unit-test", State: pkgscm.CommitStatusSuccess},
+	}
+
+	cases := []struct {
+		required []string
+		expected string
+	}{
+		{required: []string{"build", "unit-test"}, expected: ""},
+		{required: []string{"build", "e2e"}, expected: "e2e (missing)"},
+		{required: nil, expected: "lint (pending)"},
+	}
+
+	for _, item := range cases {
+		actual := strings.Join(unpassedChecks(statuses, item.required), ",")
+		if actual != item.expected {
+			t.Errorf("unpassed checks of required %v should be %q, but: %q", item.required, item.expected, actual)
+		}
+	}
+
+	if actual := unpassedChecks(nil, nil); len(actual) != 1 {
+		t.Errorf("commit without any ci status should not pass, but: %v", actual)
+	}
+}
+
+func TestCommitStatusCache(t *testing.T) {
+	ctx := context.Background()
+
+	blocked := make(chan struct{})
+	calls := map[string]int{}
+	callsLock := sync.Mutex{}
+	cache := newCommitStatusCache()
+	cache.list = func(ctx context.Context, modulePath string, sha string, token string) ([]pkgscm.CommitStatus, error) {
+		callsLock.Lock()
+		calls[sha]++
+		callsLock.Unlock()
+		if sha == "slow" {
+			<-blocked
+		}
+		return []pkgscm.CommitStatus{{Context: sha, State: pkgscm.CommitStatusSuccess}}, nil
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if statuses, err := cache.get(ctx, "github.com/demo/demo", "slow", ""); err != nil || len(statuses) != 1 {
+				t.Errorf("statuses of slow commit should be listed, but: %#v %v", statuses, err)
+			}
+		}()
+	}
+
+	// listing another commit should not wait for the slow one
+	if statuses, err := cache.get(ctx, "github.com/demo/demo", "fast", ""); err != nil || len(statuses) != 1 {
+		t.Errorf("statuses of fast commit should be listed, but: %#v %v", statuses, err)
+	}
+	close(blocked)
+	wg.Wait()
+
+	if calls["slow"] != 1 || calls["fast"] != 1 {
+		t.Errorf("each commit should be listed once, but: %v", calls)
+	}
+}
+
+func TestCIFindingsUnresolvedCommit(t *testing.T) {
+	ctx := pkgctx.WithLogger(context.Background(), zap.NewNop().Sugar())
+	require := ModRequireAnalysis{
+		Require: modfile.Require{Mod: module.Version{Path: "github.com/example/demo", Version: "v0.0.0-20230620020346-5e946b016f71"}},
+	}
+	opts := AnalysisOptions{RequireCI: true, statusCache: newCommitStatusCache()}
+
+	statuses, findings := ciFindings(ctx, "", require, "5e946b016f71", opts)
+	if len(statuses) != 0 || len(findings) != 1 || findings[0].Type != FindingCINotPassed || findings[0].Severity != SeverityError {
+		t.Errorf("unresolved commit should not pass the ci requirement, but: %#v", findings)
+	}
+}
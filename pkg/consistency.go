@@ -0,0 +1,119 @@
+package pkg
+
+import (
+	"fmt"
+	"golang.org/x/mod/semver"
+	"strings"
+)
+
+// ConsistencyMode how versions of a module required by multiple go.mod files should be consistent
+type ConsistencyMode string
+
+const (
+	// ConsistencyNone versions are not checked
+	ConsistencyNone ConsistencyMode = "none"
+	// ConsistencyVersion versions must be the same
+	ConsistencyVersion ConsistencyMode = "version"
+	// ConsistencyBranch versions must be the same or at least from the same branch
+	ConsistencyBranch ConsistencyMode = "branch"
+)
+
+// ParseConsistencyMode parses consistency mode, it should be none, version or branch
+func ParseConsistencyMode(mode string) (ConsistencyMode, error) {
+	switch ConsistencyMode(mode) {
+	case ConsistencyNone, ConsistencyVersion, ConsistencyBranch:
+		return ConsistencyMode(mode), nil
+	}
+	return "", fmt.Errorf("unknown consistency mode %q, it should be none, version or branch", mode)
+}
+
+// ApplyConsistency adds findings to requires whose version is different from the other go.mod files of the repository,
+// the suggested version is the one required by most go.mod files, or the newest one if there is a tie
+func ApplyConsistency(mods []ModRequireAnalysis, mode ConsistencyMode) {
+	if mode == "" || mode == ConsistencyNone {
+		return
+	}
+
+	paths := []string{}
+	groups := map[string][]int{}
+	for i, item := range mods {
+		if _, ok := groups[item.Mod.Path]; !ok {
+			paths = append(paths, item.Mod.Path)
+		}
+		groups[item.Mod.Path] = append(groups[item.Mod.Path], i)
+	}
+
+	for _, path := range paths {
+		indexes := groups[path]
+		suggested := majorityVersion(mods, indexes)
+		if suggested == "" {
+			continue
+		}
+
+		locations := []string{}
+		branches := map[string]bool{}
+		for _, i := range indexes {
+			if mods[i].Mod.Version != suggested {
+				continue
+			}
+			locations = append(locations, fmt.Sprintf("%s:%d", mods[i].ModFile, mods[i].Syntax.Start.Line))
+			for _, branch := range mods[i].Branches {
+				branches[branch] = true
+			}
+		}
+
+		for _, i := range indexes {
+			if mods[i].Mod.Version == suggested {
+				continue
+			}
+			if mode == ConsistencyBranch && (len(mods[i].Branches) == 0 || sameBranch(mods[i].Branches, branches)) {
+				continue
+			}
+
+			message := fmt.Sprintf("version is inconsistent, %s is required in %s", suggested, strings.Join(locations, ", "))
+			if mode == ConsistencyBranch {
+				message = fmt.Sprintf("version is not from the same branch, %s is required in %s", suggested, strings.Join(locations, ", "))
+			}
+			mods[i].Findings = append(mods[i].Findings, Finding{
+				Type:             FindingInconsistentVersion,
+				Severity:         SeverityError,
+				Message:          message,
+				SuggestedVersion: suggested,
+			})
+		}
+	}
+}
+
+// majorityVersion returns the version required by most go.mod files, or the newest one if there is a tie,
+// it returns empty if the module is required by only one go.mod file or all versions are the same
+func majorityVersion(mods []ModRequireAnalysis, indexes []int) string {
+	files := map[string]bool{}
+	counts := map[string]int{}
+	for _, i := range indexes {
+		files[mods[i].ModFile] = true
+		counts[mods[i].Mod.Version]++
+	}
+	if len(files) < 2 || len(counts) < 2 {
+		return ""
+	}
+
+	majority := ""
+	for version, count := range counts {
+		if majority == "" || count > counts[majority] || (count == counts[majority] && semver.Compare(version, majority) > 0) {
+			majority = version
+		}
+	}
+	return majority
+}
+
+func sameBranch(branches []string, reference map[string]bool) bool {
+	if len(reference) == 0 {
+		return true
+	}
+	for _, branch := range branches {
+		if reference[branch] {
+			return true
+		}
+	}
+	return false
+}
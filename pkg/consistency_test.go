@@ -0,0 +1,76 @@
+package pkg
+
+import (
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"testing"
+)
+
+func consistencyRequire(file string, line int, version string, branches ...string) ModRequireAnalysis {
+	return ModRequireAnalysis{
+		Require: modfile.Require{
+			Mod:    module.Version{Path: "github.com/example/demo", Version: version},
+			Syntax: &modfile.Line{Start: modfile.Position{Line: line}},
+		},
+		ModFile:  file,
+		Branches: branches,
+	}
+}
+
+func TestApplyConsistency(t *testing.T) {
+	cases := []struct {
+		mode     ConsistencyMode
+		mods     []ModRequireAnalysis
+		expected []string
+	}{
+		{
+			mode: ConsistencyVersion,
+			mods: []ModRequireAnalysis{
+				consistencyRequire("go.mod", 5, "v1.2.0"),
+				consistencyRequire("a/go.mod", 6, "v1.2.0"),
+				consistencyRequire("b/go.mod", 7, "v1.3.0"),
+			},
+			expected: []string{"", "", "version is inconsistent, v1.2.0 is required in go.mod:5, a/go.mod:6"},
+		},
+		{
+			// tie is resolved by the newest version
+			mode: ConsistencyVersion,
+			mods: []ModRequireAnalysis{
+				consistencyRequire("go.mod", 5, "v1.2.0"),
+				consistencyRequire("a/go.mod", 6, "v1.3.0"),
+			},
+			expected: []string{"version is inconsistent, v1.3.0 is required in a/go.mod:6", ""},
+		},
+		{
+			mode: ConsistencyBranch,
+			mods: []ModRequireAnalysis{
+				consistencyRequire("go.mod", 5, "v1.2.0", "main"),
+				consistencyRequire("a/go.mod", 6, "v1.2.0", "main"),
+				consistencyRequire("b/go.mod", 7, "v1.3.0", "main", "release-1.3"),
+				consistencyRequire("c/go.mod", 8, "v1.4.0", "feature-x"),
+			},
+			expected: []string{"", "", "", "version is not from the same branch, v1.2.0 is required in go.mod:5, a/go.mod:6"},
+		},
+		{
+			mode: ConsistencyNone,
+			mods: []ModRequireAnalysis{
+				consistencyRequire("go.mod", 5, "v1.2.0"),
+				consistencyRequire("a/go.mod", 6, "v1.3.0"),
+			},
+			expected: []string{"", ""},
+		},
+	}
+
+	for i, c := range cases {
+		ApplyConsistency(c.mods, c.mode)
+		for j, mod := range c.mods {
+			actual := ""
+			if len(mod.Findings) > 0 {
+				actual = mod.Findings[0].Message
+			}
+			if actual != c.expected[j] {
+				t.Errorf("case %d: finding of %s should be %q, but: %q", i, mod.ModFile, c.expected[j], actual)
+			}
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package pkg
+
+import (
+	"fmt"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v3"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// DenyList module versions or commits that must never be used
+type DenyList struct {
+	Entries []DenyEntry `json:"entries" yaml:"entries"`
+}
+
+// DenyEntry denied versions or commit of module, one of Versions and Commit should be provided
+type DenyEntry struct {
+	// Module module path, it supports glob pattern, eg. github.com/demo/*
+	Module string `json:"module" yaml:"module"`
+	// Versions version range, constraints are separated by space, eg. ">=v1.2.0 <v1.3.0", "v1.2.3" or "*"
+	Versions string `json:"versions,omitempty" yaml:"versions,omitempty"`
+	// Commit prefix of denied commit, it matches the revision of pseudo-version or the commit that the tag points to
+	Commit string `json:"commit,omitempty" yaml:"commit,omitempty"`
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+// ParseDenyList parses deny list file content, it could be yaml or json
+func ParseDenyList(bts []byte) (*DenyList, error) {
+	denyList := &DenyList{}
+	err := yaml.Unmarshal(bts, denyList)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, entry := range denyList.Entries {
+		if entry.Module == "" || entry.Reason == "" {
+			return nil, fmt.Errorf("module and reason of entry %d should be provided", i)
+		}
+		if (entry.Versions == "") == (entry.Commit == "") {
+			return nil, fmt.Errorf("one of versions and commit of entry %d should be provided", i)
+		}
+		if _, err := path.Match(entry.Module, ""); err != nil {
+			return nil, fmt.Errorf("module pattern '%s' of entry %d error: %s", entry.Module, i, err.Error())
+		}
+		if _, err := parseVersionConstraints(entry.Versions); err != nil {
+			return nil, fmt.Errorf("versions '%s' of entry %d error: %s", entry.Versions, i, err.Error())
+		}
+	}
+
+	return denyList, nil
+}
+
+// Matches returns true if the module version is denied by the entry,
+// commit is the full hash of the commit that the version resolved to in the repository, it is optional
+func (entry DenyEntry) Matches(version module.Version, commit string) bool {
+	if matched, _ := path.Match(entry.Module, version.Path); !matched {
+		return false
+	}
+
+	if entry.Commit != "" {
+		if commit != "" {
+			return strings.HasPrefix(commit, entry.Commit)
+		}
+		if !module.IsPseudoVersion(version.Version) {
+			return false
+		}
+		rev, err := module.PseudoVersionRev(version.Version)
+		if err != nil {
+			return false
+		}
+		return strings.HasPrefix(rev, entry.Commit) || strings.HasPrefix(entry.Commit, rev)
+	}
+
+	constraints, _ := parseVersionConstraints(entry.Versions)
+	for _, item := range constraints {
+		if !item.matches(version.Version) {
+			return false
+		}
+	}
+	return true
+}
+
+// ApplyDenyList adds error findings to the modules that denied by deny list
+func ApplyDenyList(mods []ModRequireAnalysis, denyList *DenyList) {
+	for i := range mods {
+		for j, entry := range denyList.Entries {
+			if !entry.Matches(mods[i].Mod, mods[i].Commit) {
+				continue
+			}
+
+			mods[i].Findings = append(mods[i].Findings, Finding{
+				Type:     FindingDenied,
+				Severity: SeverityError,
+				Message:  "version is denied: " + entry.Reason,
+				Key:      strconv.Itoa(j),
+			})
+		}
+	}
+}
+
+type versionConstraint struct {
+	operator string
+	version  string
+}
+
+func (constraint versionConstraint) matches(version string) bool {
+	if constraint.operator == "*" {
+		return true
+	}
+
+	compared := semver.Compare(version, constraint.version)
+	switch constraint.operator {
+	case ">=":
+		return compared >= 0
+	case ">":
+		return compared > 0
+	case "<=":
+		return compared <= 0
+	case "<":
+		return compared < 0
+	}
+	return compared == 0
+}
+
+// parseVersionConstraints parses constraints separated by space, eg. ">=v1.2.0 <v1.3.0"
+func parseVersionConstraints(versions string) ([]versionConstraint, error) {
+	constraints := []versionConstraint{}
+	for _, field := range strings.Fields(versions) {
+		if field == "*" {
+			constraints = append(constraints, versionConstraint{operator: "*"})
+			continue
+		}
+
+		constraint := versionConstraint{operator: "=", version: field}
+		for _, operator := range []string{">=", "<=", ">", "<", "="} {
+			if strings.HasPrefix(field, operator) {
+				constraint = versionConstraint{operator: operator, version: strings.TrimPrefix(field, operator)}
+				break
+			}
+		}
+		if !semver.IsValid(constraint.version) {
+			return nil, fmt.Errorf("invalid semver '%s'", constraint.version)
+		}
+		constraints = append(constraints, constraint)
+	}
+	return constraints, nil
+}
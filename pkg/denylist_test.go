@@ -0,0 +1,100 @@
+package pkg
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
+	"testing"
+)
+
+var denyListString = `
+entries:
+  - module: github.com/example/demo
+    versions: ">=v1.2.0 <v1.3.0"
+    reason: broken migration
+  - module: github.com/example/*
+    commit: 5e946b016f71
+    reason: leaked secret
+`
+
+func TestApplyDenyList(t *testing.T) {
+	denyList, err := ParseDenyList([]byte(denyListString))
+	if err != nil {
+		t.Errorf("should parse deny list correctly, but error: %s", err.Error())
+		return
+	}
+
+	cases := map[module.Version]string{
+		{Path: "github.com/example/demo", Version: "v1.2.5"}:                              "version is denied: broken migration",
+		{Path: "github.com/example/demo", Version: "v1.3.0"}:                              "",
+		{Path: "github.com/example/abc", Version: "v0.7.1-0.20230620020346-5e946b016f71"}: "version is denied: leaked secret",
+		{Path: "github.com/other/abc", Version: "v0.7.1-0.20230620020346-5e946b016f71"}:   "",
+	}
+
+	for version, expected := range cases {
+		mods := []ModRequireAnalysis{{Require: modfile.Require{Mod: version}}}
+		ApplyDenyList(mods, denyList)
+
+		actual := ""
+		if len(mods[0].Findings) > 0 {
+			actual = mods[0].Findings[0].Message
+		}
+		if actual != expected {
+			t.Errorf("finding of %s should be %q, but: %q", version, expected, actual)
+		}
+	}
+
+	if _, err := ParseDenyList([]byte(`{"entries": [{"module": "github.com/example/demo", "reason": "no version"}]}`)); err == nil {
+		t.Errorf("parse entry without versions or commit should return error")
+	}
+}
+
+func TestApplyDenyListTaggedCommit(t *testing.T) {
+	denyList, err := ParseDenyList([]byte(denyListString))
+	if err != nil {
+		t.Errorf("should parse deny list correctly, but error: %s", err.Error())
+		return
+	}
+
+	mods := []ModRequireAnalysis{
+		{
+			Require: modfile.Require{Mod: module.Version{Path: "github.com/example/tagged", Version: "v1.0.0"}},
+			Commit:  "5e946b016f71e1a2b3c4d5e6f708192a3b4c5d6e",
+		},
+		{
+			Require: modfile.Require{Mod: module.Version{Path: "github.com/example/other", Version: "v1.0.0"}},
+			Commit:  "9512b3f003839887162903d9adb5436104ee6235",
+		},
+	}
+	ApplyDenyList(mods, denyList)
+
+	if len(mods[0].Findings) != 1 || mods[0].Findings[0].Type != FindingDenied {
+		t.Errorf("tag pointing to denied commit should be denied, but: %#v", mods[0].Findings)
+	}
+	if len(mods[1].Findings) != 0 {
+		t.Errorf("tag pointing to other commit should not be denied, but: %#v", mods[1].Findings)
+	}
+}
+
+func TestResolveCommit(t *testing.T) {
+	ctx := pkgctx.WithLogger(context.Background(), zap.NewNop().Sugar())
+
+	dir := t.TempDir()
+	testGit(t, dir, "init", "-q")
+	root := testCommit(t, dir, "root")
+	testGit(t, dir, "tag", "-a", "-m", "release", "v1.0.0")
+	nested := testCommit(t, dir, "nested")
+	testGit(t, dir, "tag", "tools/v1.0.0")
+
+	if commit := resolveCommit(ctx, dir, "github.com/example/demo", "v1.0.0"); commit != root {
+		t.Errorf("annotated tag should resolve to %s, but: %s", root, commit)
+	}
+	if commit := resolveCommit(ctx, dir, "github.com/example/demo/tools", "v1.0.0"); commit != nested {
+		t.Errorf("tag of nested module should resolve to %s, but: %s", nested, commit)
+	}
+	if commit := resolveCommit(ctx, dir, "github.com/example/demo", "v9.9.9"); commit != "" {
+		t.Errorf("unknown tag should resolve to empty, but: %s", commit)
+	}
+}
@@ -0,0 +1,107 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
+	"path"
+	"strings"
+)
+
+// readDependencyModFile reads go.mod of the module at rev from the cloned repository,
+// go.mod in the sub directory of module is preferred, it returns nil if go.mod is not found
+func readDependencyModFile(ctx context.Context, dir string, modulePath string, rev string) (*modfile.File, error) {
+	subDir := moduleSubDir(modulePath)
+
+	candidates := []string{"go.mod"}
+	if subDir != "" {
+		candidates = append([]string{path.Join(subDir, "go.mod")}, candidates...)
+	}
+	// go.mod of major version could be in the major subdirectory, eg. v2/go.mod
+	if _, pathMajor, ok := module.SplitPathVersion(modulePath); ok && strings.HasPrefix(pathMajor, "/") {
+		candidates = append([]string{path.Join(subDir, pathMajor, "go.mod")}, candidates...)
+	}
+
+	for _, file := range candidates {
+		stdout, _, err := runCmd(ctx, dir, "git", "show", rev+":"+file)
+		if err != nil {
+			continue
+		}
+		return ParseModFile(modulePath+"@"+rev+"/"+file, []byte(stdout))
+	}
+	return nil, nil
+}
+
+// depModFindings inspects go.mod of the dependency at the pinned revision,
+// go directive newer than ours, module path mismatch and replace directives that ignored by consumers are reported,
+// requires of the dependency are returned too. the resolved commit is preferred to rev,
+// because tags of module in sub directory are prefixed by the sub directory
+func depModFindings(ctx context.Context, dir string, require ModRequireAnalysis, rev string, opts AnalysisOptions) ([]module.Version, []Finding) {
+	logger := pkgctx.GetLogger(ctx).With("module", require.Mod.Path, "rev", rev)
+
+	if require.Commit != "" {
+		rev = require.Commit
+	}
+	file, err := readDependencyModFile(ctx, dir, require.Mod.Path, rev)
+	if err != nil {
+		logger.Errorw("parse go.mod of dependency error", "err", err)
+		return nil, nil
+	}
+	if file == nil {
+		logger.Debugw("not found go.mod of dependency")
+		return nil, nil
+	}
+
+	requires := []module.Version{}
+	for _, item := range file.Require {
+		requires = append(requires, item.Mod)
+	}
+	return requires, inspectDependencyModFile(require.Mod.Path, file, opts.GoVersion)
+}
+
+// inspectDependencyModFile returns findings of the go.mod of dependency
+func inspectDependencyModFile(modulePath string, file *modfile.File, goVersion string) []Finding {
+	findings := []Finding{}
+
+	if file.Go != nil && goVersion != "" && goVersionNewer(file.Go.Version, goVersion) {
+		findings = append(findings, Finding{
+			Type:     FindingGoVersionIncompatible,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("go directive of dependency is %s, which is newer than %s of ours", file.Go.Version, goVersion),
+		})
+	}
+
+	if file.Module != nil && file.Module.Mod.Path != modulePath {
+		findings = append(findings, Finding{
+			Type:     FindingModulePathMismatch,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("module path of dependency is %s, which is different from %s", file.Module.Mod.Path, modulePath),
+		})
+	}
+
+	if len(file.Replace) > 0 {
+		replaces := []string{}
+		for _, item := range file.Replace {
+			replaces = append(replaces, item.Old.String()+" => "+item.New.String())
+		}
+		findings = append(findings, Finding{
+			Type:     FindingIgnoredReplace,
+			Severity: SeverityWarning,
+			Message:  "replace directives of dependency are ignored by consumers: " + strings.Join(replaces, ", "),
+		})
+	}
+
+	return findings
+}
+
+// goVersionNewer returns true if go version a is newer than b, eg. 1.21.0 is newer than 1.19
+func goVersionNewer(a string, b string) bool {
+	va, vb := "v"+a, "v"+b
+	if !semver.IsValid(va) || !semver.IsValid(vb) {
+		return false
+	}
+	return semver.Compare(va, vb) > 0
+}
@@ -0,0 +1,71 @@
+package pkg
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var dependencyModfileString = `
+module github.com/example/renamed
+
+go 1.21.0
+
+require github.com/example/abc v1.0.0
+
+replace github.com/example/abc => ../abc
+`
+
+func TestInspectDependencyModFile(t *testing.T) {
+	file, err := ParseModFile("./go.mod", []byte(dependencyModfileString))
+	if err != nil {
+		t.Errorf("should parse mod file correctly, but error: %s", err.Error())
+		return
+	}
+
+	findings := inspectDependencyModFile("github.com/example/demo", file, "1.19")
+	expected := []FindingType{FindingGoVersionIncompatible, FindingModulePathMismatch, FindingIgnoredReplace}
+	if len(findings) != len(expected) {
+		t.Errorf("findings should be %v, but: %#v", expected, findings)
+		return
+	}
+	for i := range expected {
+		if findings[i].Type != expected[i] {
+			t.Errorf("finding %d should be %s, but: %s", i, expected[i], findings[i].Type)
+		}
+	}
+
+	if findings := inspectDependencyModFile("github.com/example/renamed", file, "1.21.1"); len(findings) != 1 {
+		t.Errorf("only ignored replace should be found, but: %#v", findings)
+	}
+}
+
+func TestDepModFindingsNestedModule(t *testing.T) {
+	ctx := pkgctx.WithLogger(context.Background(), zap.NewNop().Sugar())
+
+	dir := t.TempDir()
+	testGit(t, dir, "init", "-q")
+	if err := os.Mkdir(filepath.Join(dir, "tools"), 0755); err != nil {
+		t.Fatalf("mkdir error: %s", err.Error())
+	}
+	testCommitFile(t, dir, "tools/go.mod", "module github.com/example/demo/tools\n\ngo 1.18\n")
+	testGit(t, dir, "tag", "tools/v1.0.0")
+	// tag of the root module points to a commit that tools/go.mod is changed
+	testCommitFile(t, dir, "tools/go.mod", "module github.com/example/demo/tools\n\ngo 1.21\n")
+	testGit(t, dir, "tag", "v1.0.0")
+
+	require := ModRequireAnalysis{
+		Require: modfile.Require{Mod: module.Version{Path: "github.com/example/demo/tools", Version: "v1.0.0"}},
+	}
+	require.Commit = resolveCommit(ctx, dir, require.Mod.Path, require.Mod.Version)
+
+	_, findings := depModFindings(ctx, dir, require, require.Mod.Version, AnalysisOptions{GoVersion: "1.19"})
+	if len(findings) != 0 {
+		t.Errorf("go.mod of nested module should be read at its own tag, but: %#v", findings)
+	}
+}
@@ -0,0 +1,167 @@
+package pkg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// directivePrefix prefix of the suppression directive in the trailing comment of require
+const directivePrefix = "gomod-version-lint:ignore"
+
+// Directive suppression directive in the trailing comment of require, eg.
+//
+//	github.com/demo/demo v0.0.0-20230314042448-bf45d9fa206a // gomod-version-lint:ignore reason="waiting for release" until=2026-12-01
+type Directive struct {
+	// Reason why findings of the require are suppressed, it is required, it should be quoted if it contains spaces
+	Reason string
+	// Until the last date that directive suppresses findings, format: 2006-01-02, it is optional
+	Until string
+}
+
+func (directive Directive) String() string {
+	reason := directive.Reason
+	if strings.ContainsAny(reason, " \t") {
+		reason = strconv.Quote(reason)
+	}
+	str := "directive reason=" + reason
+	if directive.Until != "" {
+		str = str + " until=" + directive.Until
+	}
+	return str
+}
+
+// ParseDirective parses suppression directive from trailing comments of require,
+// found will be false if there is no directive in comments
+func ParseDirective(require ModRequireAnalysis) (directive Directive, found bool, err error) {
+	if require.Syntax == nil {
+		return directive, false, nil
+	}
+
+	for _, comment := range require.Syntax.Suffix {
+		text := strings.TrimSpace(strings.TrimPrefix(comment.Token, "//"))
+		if !strings.HasPrefix(text, directivePrefix) {
+			continue
+		}
+		// the directive should be followed by spaces or nothing, eg. gomod-version-lint:ignored is not a directive
+		args := strings.TrimPrefix(text, directivePrefix)
+		if args != "" && !unicode.IsSpace(rune(args[0])) {
+			continue
+		}
+
+		found = true
+		fields, err := directiveFields(args)
+		if err != nil {
+			return directive, found, err
+		}
+		for _, field := range fields {
+			key, value := field[0], field[1]
+			switch key {
+			case "reason":
+				directive.Reason = value
+			case "until":
+				if _, err = time.Parse(baselineDateLayout, value); err != nil {
+					return directive, found, fmt.Errorf("until '%s' should be formatted as 2006-01-02", value)
+				}
+				directive.Until = value
+			default:
+				return directive, found, fmt.Errorf("unknown key '%s'", key)
+			}
+		}
+
+		if directive.Reason == "" {
+			return directive, found, fmt.Errorf("reason should be provided")
+		}
+		return directive, found, nil
+	}
+
+	return directive, false, nil
+}
+
+// directiveFields splits arguments of directive into key and value pairs separated by spaces,
+// value could be quoted by double quotes to contain spaces, eg. reason="waiting for release"
+func directiveFields(args string) ([][2]string, error) {
+	fields := [][2]string{}
+	for {
+		args = strings.TrimLeftFunc(args, unicode.IsSpace)
+		if args == "" {
+			return fields, nil
+		}
+
+		end := strings.IndexFunc(args, func(r rune) bool { return r == '=' || unicode.IsSpace(r) })
+		if end < 0 || args[end] != '=' {
+			key, _, _ := strings.Cut(args, " ")
+			return nil, fmt.Errorf("'%s' should be formatted as key=value, values with spaces should be quoted", key)
+		}
+		key, value := args[:end], ""
+		args = args[end+1:]
+
+		if strings.HasPrefix(args, `"`) {
+			closing := strings.Index(args[1:], `"`)
+			if closing < 0 {
+				return nil, fmt.Errorf("value of '%s' is not closed by quote", key)
+			}
+			value, args = args[1:closing+1], args[closing+2:]
+			if args != "" && !unicode.IsSpace(rune(args[0])) {
+				return nil, fmt.Errorf("value of '%s' should be followed by space", key)
+			}
+		} else {
+			end = strings.IndexFunc(args, unicode.IsSpace)
+			if end < 0 {
+				end = len(args)
+			}
+			value, args = args[:end], args[end:]
+		}
+		fields = append(fields, [2]string{key, value})
+	}
+}
+
+// ApplyDirectives suppresses findings of modules according to the directives in trailing comments of requires,
+// it adds findings for invalid, expired or unused directives
+func ApplyDirectives(mods []ModRequireAnalysis, now time.Time) {
+	for i := range mods {
+		directive, found, err := ParseDirective(mods[i])
+		if !found {
+			continue
+		}
+
+		if err != nil {
+			mods[i].Findings = append(mods[i].Findings, Finding{
+				Type:     FindingDirectiveInvalid,
+				Severity: SeverityError,
+				Message:  "invalid " + directivePrefix + " directive: " + err.Error(),
+			})
+			continue
+		}
+
+		if directive.Until != "" {
+			if expired, _ := dateExpired(directive.Until, now); expired {
+				mods[i].Findings = append(mods[i].Findings, Finding{
+					Type:     FindingDirectiveExpired,
+					Severity: SeverityWarning,
+					Message:  fmt.Sprintf("%s directive is expired after %s", directivePrefix, directive.Until),
+				})
+				continue
+			}
+		}
+
+		used := false
+		for j := range mods[i].Findings {
+			if mods[i].Findings[j].SuppressedBy != "" || !mods[i].Findings[j].Type.Suppressible() {
+				continue
+			}
+			mods[i].Findings[j].SuppressedBy = directive.String()
+			used = true
+		}
+
+		if !used {
+			mods[i].Findings = append(mods[i].Findings, Finding{
+				Type:     FindingDirectiveUnused,
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("%s directive is unused, there is nothing to suppress", directivePrefix),
+			})
+		}
+	}
+}
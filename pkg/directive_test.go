@@ -0,0 +1,156 @@
+package pkg
+
+import (
+	"golang.org/x/mod/modfile"
+	"testing"
+	"time"
+)
+
+var directiveModfileString = `
+module github.com/chengjingtao/gomod-version-lint
+
+go 1.19
+
+require (
+	github.com/example/ignored v1.0.0 // gomod-version-lint:ignore reason=waiting-for-release until=2026-12-01
+	github.com/example/expired v1.0.0 // gomod-version-lint:ignore reason=waiting-for-release until=2023-01-01
+	github.com/example/noreason v1.0.0 // gomod-version-lint:ignore until=2026-12-01
+	github.com/example/unused v1.0.0 // gomod-version-lint:ignore reason=waiting-for-release
+	github.com/example/none v1.0.0 // indirect
+)
+`
+
+func TestApplyDirectives(t *testing.T) {
+	file, err := ParseModFile("./go.mod", []byte(directiveModfileString))
+	if err != nil {
+		t.Errorf("should parse mod file correctly, but error: %s", err.Error())
+		return
+	}
+
+	mods := []ModRequireAnalysis{}
+	for _, item := range file.Require {
+		mod := ModRequireAnalysis{Require: *item}
+		if item.Mod.Path != "github.com/example/unused" {
+			mod.Findings = []Finding{{Type: FindingBranchNotAllowed}}
+		}
+		mods = append(mods, mod)
+	}
+
+	ApplyDirectives(mods, time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local))
+
+	expected := []struct {
+		suppressed bool
+		findings   []FindingType
+	}{
+		{suppressed: true, findings: []FindingType{FindingBranchNotAllowed}},
+		{suppressed: false, findings: []FindingType{FindingBranchNotAllowed, FindingDirectiveExpired}},
+		{suppressed: false, findings: []FindingType{FindingBranchNotAllowed, FindingDirectiveInvalid}},
+		{suppressed: false, findings: []FindingType{FindingDirectiveUnused}},
+		{suppressed: false, findings: []FindingType{FindingBranchNotAllowed}},
+	}
+
+	for i, item := range expected {
+		mod := mods[i]
+		if len(mod.Findings) != len(item.findings) {
+			t.Errorf("findings of %s should be %v, but: %#v", mod.Mod.Path, item.findings, mod.Findings)
+			continue
+		}
+		for j, findingType := range item.findings {
+			if mod.Findings[j].Type != findingType {
+				t.Errorf("finding %d of %s should be %s, but: %s", j, mod.Mod.Path, findingType, mod.Findings[j].Type)
+			}
+		}
+		if (mod.Findings[0].SuppressedBy != "") != item.suppressed {
+			t.Errorf("suppressed of %s should be %v, but: %#v", mod.Mod.Path, item.suppressed, mod.Findings[0])
+		}
+	}
+}
+
+func TestApplyDirectivesUnsuppressible(t *testing.T) {
+	file, err := ParseModFile("./go.mod", []byte(directiveModfileString))
+	if err != nil {
+		t.Errorf("should parse mod file correctly, but error: %s", err.Error())
+		return
+	}
+
+	mods := []ModRequireAnalysis{
+		{
+			Require: *file.Require[0],
+			Findings: []Finding{
+				{Type: FindingDenied, Severity: SeverityError},
+				{Type: FindingVulnerability, Severity: SeverityError},
+			},
+		},
+	}
+	ApplyDirectives(mods, time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local))
+
+	for _, finding := range mods[0].Findings[:2] {
+		if finding.SuppressedBy != "" {
+			t.Errorf("%s finding should not be suppressed by directive, but: %#v", finding.Type, finding)
+		}
+	}
+	if len(mods[0].Findings) != 3 || mods[0].Findings[2].Type != FindingDirectiveUnused {
+		t.Errorf("directive should be unused when nothing could be suppressed, but: %#v", mods[0].Findings)
+	}
+}
+
+func TestParseDirective(t *testing.T) {
+	cases := []struct {
+		comment  string
+		found    bool
+		reason   string
+		until    string
+		hasError bool
+	}{
+		{comment: `// gomod-version-lint:ignore reason=waiting-for-release`, found: true, reason: "waiting-for-release"},
+		{comment: `// gomod-version-lint:ignore reason="waiting for upstream release" until=2026-12-01`, found: true, reason: "waiting for upstream release", until: "2026-12-01"},
+		{comment: `// gomod-version-lint:ignore until=2026-12-01 reason="waiting for upstream release"`, found: true, reason: "waiting for upstream release", until: "2026-12-01"},
+		{comment: `// gomod-version-lint:ignore reason=waiting for upstream release`, found: true, reason: "waiting", hasError: true},
+		{comment: `// gomod-version-lint:ignore reason="waiting for upstream release`, found: true, hasError: true},
+		{comment: `// gomod-version-lint:ignore reason`, found: true, hasError: true},
+		{comment: `// gomod-version-lint:ignore`, found: true, hasError: true},
+		{comment: `// gomod-version-lint:ignorefoo reason=waiting-for-release`, found: false},
+		{comment: `// indirect`, found: false},
+	}
+
+	for _, item := range cases {
+		require := ModRequireAnalysis{
+			Require: modfile.Require{Syntax: &modfile.Line{Comments: modfile.Comments{Suffix: []modfile.Comment{{Token: item.comment}}}}},
+		}
+		directive, found, err := ParseDirective(require)
+		if found != item.found || (err != nil) != item.hasError {
+			t.Errorf("%s should be found %v with error %v, but: %v %v", item.comment, item.found, item.hasError, found, err)
+			continue
+		}
+		if err == nil && (directive.Reason != item.reason || directive.Until != item.until) {
+			t.Errorf("%s should be parsed as reason %q until %q, but: %#v", item.comment, item.reason, item.until, directive)
+		}
+	}
+}
+
+func TestApplyDirectivesUntil(t *testing.T) {
+	cases := []struct {
+		now     time.Time
+		expired bool
+	}{
+		{now: time.Date(2026, 12, 1, 23, 59, 59, 0, time.Local), expired: false},
+		{now: time.Date(2026, 12, 2, 0, 0, 0, 0, time.Local), expired: true},
+	}
+
+	for _, item := range cases {
+		mods := []ModRequireAnalysis{
+			{
+				Require: modfile.Require{Syntax: &modfile.Line{Comments: modfile.Comments{Suffix: []modfile.Comment{
+					{Token: `// gomod-version-lint:ignore reason="waiting for release" until=2026-12-01`},
+				}}}},
+				Findings: []Finding{{Type: FindingBranchNotAllowed}},
+			},
+		}
+		ApplyDirectives(mods, item.now)
+
+		expired := len(mods[0].Findings) == 2 && mods[0].Findings[1].Type == FindingDirectiveExpired
+		if expired != item.expired || (mods[0].Findings[0].SuppressedBy == "") != item.expired {
+			t.Errorf("directive until 2026-12-01 should be expired %v at %s, but: %#v", item.expired, item.now, mods[0].Findings)
+		}
+	}
+}
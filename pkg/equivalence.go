@@ -0,0 +1,285 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxEquivalenceCandidates max count of commits on each allowed branch to compare changed files and patch-id with
+const maxEquivalenceCandidates = "200"
+
+// equivalentFindings searches allowed branches for the commit which is squash-merged or cherry-picked from rev,
+// it is used when rev is not reachable from any allowed branch
+func equivalentFindings(ctx context.Context, dir string, require ModRequireAnalysis, rev string, opts AnalysisOptions) []Finding {
+	logger := pkgctx.GetLogger(ctx).With("module", require.Mod.Path, "rev", rev)
+
+	if !module.IsPseudoVersion(require.Mod.Version) || opts.AllowedBranchesRegex == "" {
+		return nil
+	}
+
+	sha := require.Commit
+	if sha == "" {
+		logger.Debugw("commit is not resolved, skip searching equivalent commit")
+		return nil
+	}
+
+	branches, err := allowedRemoteBranches(ctx, dir, opts.AllowedBranchesRegex)
+	if err != nil {
+		logger.Errorw("list allowed branches error", "err", err)
+		return nil
+	}
+
+	for _, branch := range branches {
+		merged, err := equivalentCommit(ctx, dir, sha, "origin/"+branch)
+		if err != nil {
+			logger.Errorw("search equivalent commit error", "branch", branch, "err", err)
+			continue
+		}
+		if merged == "" {
+			continue
+		}
+
+		version, err := pseudoVersion(ctx, dir, require.Mod.Path, merged)
+		if err != nil {
+			logger.Errorw("make pseudo-version error", "commit", merged, "err", err)
+		}
+
+		return []Finding{
+			{
+				Type:             FindingMergedEquivalent,
+				Severity:         SeverityInfo,
+				Message:          fmt.Sprintf("merged as %s on %s", shortSha(merged), branch),
+				SuggestedVersion: version,
+			},
+		}
+	}
+
+	return nil
+}
+
+// allowedRemoteBranches lists remote branches which match the regex
+func allowedRemoteBranches(ctx context.Context, dir string, allowedBranchesRegex string) ([]string, error) {
+	stdout, _, err := runCmd(ctx, dir, "git", "for-each-ref", "--format=%(refname:lstrip=3)", "refs/remotes/origin")
+	if err != nil {
+		return nil, err
+	}
+
+	branches := []string{}
+	for _, item := range strings.Split(stdout, "\n") {
+		item = strings.TrimSpace(item)
+		if item == "" || item == "HEAD" {
+			continue
+		}
+		branches = append(branches, item)
+	}
+
+	return MatchedBranches(branches, allowedBranchesRegex)
+}
+
+// equivalentCommit returns the commit on branch that has cherry-pick trailer of sha,
+// or has the same patch-id with sha, or with all changes of sha since the merge base when it is squash-merged.
+// it returns empty if not found
+func equivalentCommit(ctx context.Context, dir string, sha string, branch string) (string, error) {
+	stdout, _, err := runCmd(ctx, dir, "git", "log", "--format=%H", "-F", "--grep=cherry picked from commit "+sha, branch)
+	if err != nil {
+		return "", err
+	}
+	if commits := strings.Fields(stdout); len(commits) > 0 {
+		return commits[len(commits)-1], nil
+	}
+
+	// changed files are compared before patch-ids, they only read trees,
+	// so blobs are fetched lazily by the clone without blobs only for the commits that change the same files
+	files, _, err := runCmd(ctx, dir, "git", "show", "--format=", "--name-only", "--no-renames", sha)
+	if err != nil {
+		return "", err
+	}
+	fileSets := []string{normalizeFiles(strings.Split(files, "\n"))}
+
+	mergeBase, _, err := runCmd(ctx, dir, "git", "merge-base", sha, branch)
+	mergeBase = strings.TrimSpace(mergeBase)
+	if err != nil {
+		mergeBase = ""
+	}
+	if mergeBase != "" {
+		files, _, err := runCmd(ctx, dir, "git", "diff", "--name-only", "--no-renames", mergeBase, sha)
+		if err == nil {
+			fileSets = append(fileSets, normalizeFiles(strings.Split(files, "\n")))
+		}
+	}
+
+	committed, _, err := runCmd(ctx, dir, "git", "show", "-s", "--format=%ct", sha)
+	if err != nil {
+		return "", err
+	}
+	// only commits after the merge base could be merged from sha
+	revRange := branch
+	if mergeBase != "" {
+		revRange = mergeBase + ".." + branch
+	}
+	stdout, _, err = runCmd(ctx, dir, "git", "log", "--no-merges", "--max-count="+maxEquivalenceCandidates,
+		"--since=@"+strings.TrimSpace(committed), "--format="+commitFilesPrefix+"%H", "--name-only", "--no-renames", revRange)
+	if err != nil {
+		return "", err
+	}
+
+	candidates := []string{}
+	for _, item := range parseCommitFiles(stdout) {
+		for _, fileSet := range fileSets {
+			if item.files == fileSet {
+				candidates = append(candidates, item.commit)
+				break
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return "", nil
+	}
+
+	patchIDs := []string{}
+	commitPatch, _, err := runCmd(ctx, dir, "git", "show", "--format=", sha)
+	if err != nil {
+		return "", err
+	}
+	if id := parsePatchIDs(runPatchID(ctx, dir, commitPatch)); len(id) > 0 {
+		patchIDs = append(patchIDs, id[0].patchID)
+	}
+	if mergeBase != "" {
+		squashPatch, _, err := runCmd(ctx, dir, "git", "diff", mergeBase, sha)
+		if err == nil {
+			if id := parsePatchIDs(runPatchID(ctx, dir, squashPatch)); len(id) > 0 {
+				patchIDs = append(patchIDs, id[0].patchID)
+			}
+		}
+	}
+	if len(patchIDs) == 0 {
+		return "", nil
+	}
+
+	patches, _, err := runCmd(ctx, dir, "git", append([]string{"show", "--format=medium"}, candidates...)...)
+	if err != nil {
+		return "", err
+	}
+	for _, item := range parsePatchIDs(runPatchID(ctx, dir, patches)) {
+		for _, patchID := range patchIDs {
+			if item.patchID == patchID {
+				return item.commit, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// commitFilesPrefix prefix of the line of commit in output of `git log --name-only`
+const commitFilesPrefix = "commit "
+
+type commitFiles struct {
+	commit string
+	// files normalized changed files of the commit
+	files string
+}
+
+// parseCommitFiles parses output of `git log --format="commit %H" --name-only`
+func parseCommitFiles(stdout string) []commitFiles {
+	res := []commitFiles{}
+	files := []string{}
+	for _, line := range strings.Split(stdout, "\n") {
+		if !strings.HasPrefix(line, commitFilesPrefix) {
+			files = append(files, line)
+			continue
+		}
+		if len(res) > 0 {
+			res[len(res)-1].files = normalizeFiles(files)
+		}
+		res = append(res, commitFiles{commit: strings.TrimSpace(strings.TrimPrefix(line, commitFilesPrefix))})
+		files = []string{}
+	}
+	if len(res) > 0 {
+		res[len(res)-1].files = normalizeFiles(files)
+	}
+	return res
+}
+
+// normalizeFiles sorts file names and joins them so that sets of files could be compared
+func normalizeFiles(files []string) string {
+	res := []string{}
+	for _, file := range files {
+		if file = strings.TrimSpace(file); file != "" {
+			res = append(res, file)
+		}
+	}
+	sort.Strings(res)
+	return strings.Join(res, "\n")
+}
+
+type commitPatchID struct {
+	patchID string
+	commit  string
+}
+
+func runPatchID(ctx context.Context, dir string, patch string) string {
+	if strings.TrimSpace(patch) == "" {
+		return ""
+	}
+	stdout, _, err := runCmdWith(ctx, dir, strings.NewReader(patch), nil, "git", "patch-id", "--stable")
+	if err != nil {
+		pkgctx.GetLogger(ctx).Errorw("compute patch-id error", "err", err)
+		return ""
+	}
+	return stdout
+}
+
+// parsePatchIDs parses output of `git patch-id`, each line is formatted as "<patch-id> <commit-id>"
+func parsePatchIDs(stdout string) []commitPatchID {
+	res := []commitPatchID{}
+	for _, line := range strings.Split(stdout, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		res = append(res, commitPatchID{patchID: fields[0], commit: fields[1]})
+	}
+	return res
+}
+
+// pseudoVersion makes pseudo-version of the commit, base version is the latest semver tag reachable from the commit
+func pseudoVersion(ctx context.Context, dir string, modulePath string, sha string) (string, error) {
+	committed, _, err := runCmd(ctx, dir, "git", "show", "-s", "--format=%ct", sha)
+	if err != nil {
+		return "", err
+	}
+	unix, err := strconv.ParseInt(strings.TrimSpace(committed), 10, 64)
+	if err != nil {
+		return "", err
+	}
+
+	major := ""
+	if _, pathMajor, ok := module.SplitPathVersion(modulePath); ok {
+		major = strings.TrimPrefix(strings.TrimPrefix(pathMajor, "/"), ".")
+	}
+
+	older := ""
+	tag, _, err := runCmd(ctx, dir, "git", "describe", "--tags", "--abbrev=0", "--match=v*", sha)
+	if err == nil {
+		tag = strings.TrimSpace(tag)
+		if semver.IsValid(tag) && (major == "" && (semver.Major(tag) == "v0" || semver.Major(tag) == "v1") || semver.Major(tag) == major) {
+			older = tag
+		}
+	}
+
+	return module.PseudoVersion(major, older, time.Unix(unix, 0), shortSha(sha)), nil
+}
+
+func shortSha(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
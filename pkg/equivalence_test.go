@@ -0,0 +1,107 @@
+package pkg
+
+import (
+	"context"
+	"go.uber.org/zap"
+	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParsePatchIDs(t *testing.T) {
+	stdout := `1b5ec8e3bb4d1c7f5b47a8c2c8d3b2a4e0d9f6c1 9512b3f003839887162903d9adb5436104ee6235
+invalid
+
+6f0d2f9c8e4b3a2d1c0b9a8f7e6d5c4b3a2f1e0d 0000000000000000000000000000000000000000
+`
+
+	ids := parsePatchIDs(stdout)
+	if len(ids) != 2 {
+		t.Errorf("patch ids length should be 2, but: %#v", ids)
+		return
+	}
+	if ids[0].patchID != "1b5ec8e3bb4d1c7f5b47a8c2c8d3b2a4e0d9f6c1" || ids[0].commit != "9512b3f003839887162903d9adb5436104ee6235" {
+		t.Errorf("first patch id is not correct: %#v", ids[0])
+	}
+}
+
+func TestParseCommitFiles(t *testing.T) {
+	stdout := `commit 9512b3f003839887162903d9adb5436104ee6235
+
+b.go
+a.go
+commit 0000000000000000000000000000000000000000
+
+`
+
+	items := parseCommitFiles(stdout)
+	if len(items) != 2 {
+		t.Errorf("commits length should be 2, but: %#v", items)
+		return
+	}
+	if items[0].commit != "9512b3f003839887162903d9adb5436104ee6235" || items[0].files != "a.go\nb.go" {
+		t.Errorf("first commit is not correct: %#v", items[0])
+	}
+	if items[1].files != "" {
+		t.Errorf("files of commit without changes should be empty: %#v", items[1])
+	}
+}
+
+// testCommitFile writes content to file in dir and commits it, returns hash of the commit
+func testCommitFile(t *testing.T, dir string, file string, content string) string {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0644); err != nil {
+		t.Fatalf("write file error: %s", err.Error())
+	}
+	testGit(t, dir, "add", file)
+	return testCommit(t, dir, "change "+file)
+}
+
+func TestEquivalentCommit(t *testing.T) {
+	ctx := pkgctx.WithLogger(context.Background(), zap.NewNop().Sugar())
+
+	dir := t.TempDir()
+	testGit(t, dir, "init", "-q")
+	testCommitFile(t, dir, "main.go", "package main\n")
+	testGit(t, dir, "tag", "v1.2.0")
+
+	testGit(t, dir, "checkout", "-q", "-b", "picked")
+	picked := testCommitFile(t, dir, "picked.go", "package picked\n")
+	testGit(t, dir, "checkout", "-q", "-b", "squashed", "main")
+	testCommitFile(t, dir, "squashed.go", "package squashed\n")
+	squashed := testCommitFile(t, dir, "squashed.go", "package squashed\n\nconst Squashed = true\n")
+
+	testGit(t, dir, "checkout", "-q", "main")
+	testCommitFile(t, dir, "other.go", "package other\n")
+	testGit(t, dir, "cherry-pick", picked)
+	pickedMerged := testGit(t, dir, "rev-parse", "HEAD")
+	testGit(t, dir, "merge", "-q", "--squash", "squashed")
+	squashedMerged := testCommit(t, dir, "squash")
+
+	cases := map[string]string{picked: pickedMerged, squashed: squashedMerged}
+	for sha, expected := range cases {
+		actual, err := equivalentCommit(ctx, dir, sha, "main")
+		if err != nil {
+			t.Errorf("search equivalent commit of %s should not return error, but: %s", sha, err.Error())
+			continue
+		}
+		if actual != expected {
+			t.Errorf("equivalent commit of %s should be %s, but: %s", sha, expected, actual)
+		}
+	}
+
+	if actual, _ := equivalentCommit(ctx, dir, picked, "squashed"); actual != "" {
+		t.Errorf("equivalent commit should not be found on branch which does not merge it, but: %s", actual)
+	}
+
+	version, err := pseudoVersion(ctx, dir, "git.example.com/demo/demo", squashedMerged)
+	if err != nil {
+		t.Errorf("make pseudo-version should not return error, but: %s", err.Error())
+		return
+	}
+	if !strings.HasPrefix(version, "v1.2.1-0.") || !strings.HasSuffix(version, "-"+squashedMerged[:12]) {
+		t.Errorf("pseudo-version should be based on v1.2.0 and end with the short sha, but: %s", version)
+	}
+}
@@ -0,0 +1,183 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrorClass stable code of the class of analysis error
+type ErrorClass string
+
+const (
+	// ErrRepoNotFound repository of module is not found
+	ErrRepoNotFound ErrorClass = "repo-not-found"
+	// ErrAuthDenied access to the repository of module is denied
+	ErrAuthDenied ErrorClass = "auth-denied"
+	// ErrCommitNotFound commit or tag of version is not found in the repository
+	ErrCommitNotFound ErrorClass = "commit-not-found"
+	// ErrCommitUnreachable commit of version is not reachable from any branch
+	ErrCommitUnreachable ErrorClass = "commit-unreachable"
+	// ErrTimeout analysis of module timed out
+	ErrTimeout ErrorClass = "timeout"
+	// ErrUnsupportedVCS repository of module is not a git repository
+	ErrUnsupportedVCS ErrorClass = "unsupported-vcs"
+	// ErrUnknown errors could not be classified
+	ErrUnknown ErrorClass = "unknown"
+)
+
+// ErrorClasses all classes of analysis error
+var ErrorClasses = []ErrorClass{
+	ErrRepoNotFound, ErrAuthDenied, ErrCommitNotFound, ErrCommitUnreachable, ErrTimeout, ErrUnsupportedVCS, ErrUnknown,
+}
+
+// AnalysisError classified error of module analysis
+type AnalysisError struct {
+	Class   ErrorClass
+	Message string
+	// Err the underlying error, it is optional
+	Err error
+}
+
+func (e *AnalysisError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Class, e.Message)
+}
+
+func (e *AnalysisError) Unwrap() error {
+	return e.Err
+}
+
+type analysisErrorDocument struct {
+	Class   ErrorClass `json:"class" yaml:"class"`
+	Message string     `json:"message" yaml:"message"`
+}
+
+func (e *AnalysisError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(analysisErrorDocument{Class: e.Class, Message: e.Message})
+}
+
+func (e *AnalysisError) MarshalYAML() (interface{}, error) {
+	return analysisErrorDocument{Class: e.Class, Message: e.Message}, nil
+}
+
+// classifyGitError classifies error of git command according to its stderr
+func classifyGitError(ctx context.Context, err error, stderr string) *AnalysisError {
+	message := strings.TrimSpace(stderr)
+	if message == "" {
+		message = err.Error()
+	}
+	if index := strings.LastIndex(message, "\n"); index >= 0 {
+		message = strings.TrimSpace(message[index+1:])
+	}
+
+	lower := strings.ToLower(stderr)
+	class := ErrUnknown
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded) || strings.Contains(lower, "timed out"):
+		class = ErrTimeout
+	case strings.Contains(lower, "malformed object name") || strings.Contains(lower, "no such commit") ||
+		strings.Contains(lower, "unknown revision"):
+		class = ErrCommitNotFound
+	case strings.Contains(lower, "authentication failed") || strings.Contains(lower, "could not read username") ||
+		strings.Contains(lower, "terminal prompts disabled") || strings.Contains(lower, "permission denied") ||
+		hasHTTPStatus(lower, "403"):
+		class = ErrAuthDenied
+	case strings.Contains(lower, "repository not found") || strings.Contains(lower, "not found") && strings.Contains(lower, "repository") ||
+		hasHTTPStatus(lower, "404"):
+		class = ErrRepoNotFound
+	case strings.Contains(lower, "not a git repository") || strings.Contains(lower, "does not appear to be a git repository"):
+		class = ErrUnsupportedVCS
+	}
+
+	return &AnalysisError{Class: class, Message: message, Err: err}
+}
+
+// hasHTTPStatus returns true if the lower cased stderr of git reports the http status code,
+// status codes are matched with their context so that commit hashes containing the digits are not matched
+func hasHTTPStatus(lower string, code string) bool {
+	for _, format := range []string{"http %s", "error: %s", "returned error: %s"} {
+		if strings.Contains(lower, fmt.Sprintf(format, code)) {
+			return true
+		}
+	}
+	return false
+}
+
+// vcsQualifiers qualifiers in module path which indicate the repository is not git, see `go help importpath`
+var vcsQualifiers = []string{".hg", ".svn", ".bzr", ".fossil"}
+
+// checkVCS returns error if the module path indicates that the repository is not git
+func checkVCS(modulePath string) *AnalysisError {
+	for _, segment := range strings.Split(modulePath, "/") {
+		for _, qualifier := range vcsQualifiers {
+			if strings.HasSuffix(segment, qualifier) {
+				return &AnalysisError{
+					Class:   ErrUnsupportedVCS,
+					Message: fmt.Sprintf("vcs %s of module %s is not supported", strings.TrimPrefix(qualifier, "."), modulePath),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ErrorPolicy severity of findings for each class of analysis error,
+// classes that are not in the policy will be SeverityError
+type ErrorPolicy map[ErrorClass]Severity
+
+// SeverityIgnore severity in ErrorPolicy that means errors of the class are ignored
+const SeverityIgnore Severity = "ignore"
+
+// ParseErrorPolicy parses error policy from map of class to severity, severity could be info, warning, error or ignore
+func ParseErrorPolicy(policy map[string]string) (ErrorPolicy, error) {
+	res := ErrorPolicy{}
+	for class, severity := range policy {
+		known := false
+		for _, item := range ErrorClasses {
+			if item == ErrorClass(class) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown error class '%s'", class)
+		}
+
+		if Severity(severity) == SeverityIgnore {
+			res[ErrorClass(class)] = SeverityIgnore
+			continue
+		}
+		s, err := ParseSeverity(severity)
+		if err != nil {
+			return nil, err
+		}
+		res[ErrorClass(class)] = s
+	}
+	return res, nil
+}
+
+// Severity returns severity of the class in policy
+func (policy ErrorPolicy) Severity(class ErrorClass) Severity {
+	if severity, ok := policy[class]; ok {
+		return severity
+	}
+	return SeverityError
+}
+
+// errorFindings returns finding of the analysis error according to the policy
+func errorFindings(err *AnalysisError, policy ErrorPolicy) []Finding {
+	severity := policy.Severity(err.Class)
+	if severity == SeverityIgnore {
+		return nil
+	}
+
+	return []Finding{
+		{
+			Type:     FindingAnalysisError,
+			Severity: severity,
+			Message:  err.Error(),
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestClassifyGitError(t *testing.T) {
+	ctx := context.Background()
+	err := errors.New("exit status 128")
+
+	cases := map[string]ErrorClass{
+		"Cloning into '.'...\nremote: Repository not found.\nfatal: repository 'https://github.com/demo/none/' not found":         ErrRepoNotFound,
+		"fatal: could not read Username for 'https://gitlab.example': terminal prompts disabled":                                  ErrAuthDenied,
+		"fatal: unable to access 'https://github.com/demo/demo/': Failed to connect to github.com port 443: Connection timed out": ErrTimeout,
+		"error: malformed object name bf45d9fa206a":                                                                               ErrCommitNotFound,
+		"fatal: https://example.com/demo/info/refs not valid: is this a git repository?\nfatal: not a git repository":             ErrUnsupportedVCS,
+		"fatal: unable to access 'https://github.com/demo/demo/': The requested URL returned error: 403":                          ErrAuthDenied,
+		"fatal: unable to access 'https://github.com/demo/none/': The requested URL returned error: 404":                          ErrRepoNotFound,
+		"error: malformed object name 4030404a206a":                                                                               ErrCommitNotFound,
+		"fatal: reference is not a tree: 1403c4041a2b":                                                                            ErrUnknown,
+		"fatal: unexpected": ErrUnknown,
+	}
+
+	for stderr, expected := range cases {
+		actual := classifyGitError(ctx, err, stderr)
+		if actual.Class != expected {
+			t.Errorf("class of %q should be %s, but: %s", stderr, expected, actual.Class)
+		}
+	}
+}
+
+func TestAnalysisErrorMarshalJSON(t *testing.T) {
+	require := ModRequireAnalysis{
+		Error: &AnalysisError{Class: ErrAuthDenied, Message: "terminal prompts disabled", Err: errors.New("exit status 128")},
+	}
+
+	bts, err := json.Marshal(require.Error)
+	if err != nil {
+		t.Errorf("marshal analysis error should not return error, but: %s", err.Error())
+		return
+	}
+
+	expected := `{"class":"auth-denied","message":"terminal prompts disabled"}`
+	if string(bts) != expected {
+		t.Errorf("json of analysis error should be %s, but: %s", expected, string(bts))
+	}
+}
+
+func TestErrorPolicy(t *testing.T) {
+	policy, err := ParseErrorPolicy(map[string]string{"timeout": "warning", "auth-denied": "ignore"})
+	if err != nil {
+		t.Errorf("parse error policy should not return error, but: %s", err.Error())
+		return
+	}
+
+	findings := errorFindings(&AnalysisError{Class: ErrTimeout, Message: "timed out"}, policy)
+	if len(findings) != 1 || findings[0].Severity != SeverityWarning {
+		t.Errorf("finding of timeout should be warning, but: %#v", findings)
+	}
+	if findings := errorFindings(&AnalysisError{Class: ErrAuthDenied}, policy); len(findings) != 0 {
+		t.Errorf("auth-denied should be ignored, but: %#v", findings)
+	}
+	if findings := errorFindings(&AnalysisError{Class: ErrRepoNotFound}, policy); len(findings) != 1 || findings[0].Severity != SeverityError {
+		t.Errorf("finding of class not in policy should be error, but: %#v", findings)
+	}
+
+	if _, err := ParseErrorPolicy(map[string]string{"unknown-class": "error"}); err == nil {
+		t.Errorf("parse unknown class should return error")
+	}
+}
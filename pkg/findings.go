@@ -0,0 +1,153 @@
+package pkg
+
+import (
+	"context"
+	"strings"
+)
+
+// FindingType type of finding
+type FindingType string
+
+const (
+	// FindingBranchNotAllowed none of the branches that contain the version is allowed
+	FindingBranchNotAllowed FindingType = "branch-not-allowed"
+	// FindingAnalysisError analysis of the module failed, eg. repository not found or commit is unreachable
+	FindingAnalysisError FindingType = "analysis-error"
+	// FindingBranchNotFound not found any branch that contains the version
+	//
+	// Deprecated: it is reported as FindingAnalysisError now,
+	// entries of baseline recorded with it are migrated to FindingAnalysisError when parsing baseline
+	FindingBranchNotFound FindingType = "branch-not-found"
+	// FindingPullRequestOnly commit is only reachable from pull requests or merge requests
+	FindingPullRequestOnly FindingType = "pull-request-only"
+	// FindingUnreachableCommit commit is served by the repository but not reachable from any branch or pull request,
+	// eg. it only exists in a fork or on a deleted branch
+	FindingUnreachableCommit FindingType = "unreachable-commit"
+	// FindingMergedEquivalent an equivalent commit is squash-merged or cherry-picked on the allowed branch
+	FindingMergedEquivalent FindingType = "merged-equivalent"
+	// FindingUpstreamPullRequest an open or merged upstream pull request contains the commit
+	FindingUpstreamPullRequest FindingType = "upstream-pull-request"
+	// FindingCINotPassed required ci checks of the commit are not succeeded
+	FindingCINotPassed FindingType = "ci-not-passed"
+	// FindingUnsigned neither the pinned commit nor the tag is signed
+	FindingUnsigned FindingType = "unsigned"
+	// FindingUntrustedSignature signature of the pinned commit or tag is not trusted
+	FindingUntrustedSignature FindingType = "untrusted-signature"
+	// FindingGoVersionIncompatible go directive of dependency is newer than ours
+	FindingGoVersionIncompatible FindingType = "go-version-incompatible"
+	// FindingModulePathMismatch module path in go.mod of dependency is different from the required path
+	FindingModulePathMismatch FindingType = "module-path-mismatch"
+	// FindingIgnoredReplace replace directives in go.mod of dependency are ignored by consumers
+	FindingIgnoredReplace FindingType = "ignored-replace"
+	// FindingRetracted the required version is retracted in the latest go.mod of module
+	FindingRetracted FindingType = "retracted"
+	// FindingDeprecated module is deprecated in its latest go.mod
+	FindingDeprecated FindingType = "deprecated"
+	// FindingDenied the version or commit is in the deny list
+	FindingDenied FindingType = "denied"
+	// FindingVulnerability the version is affected by a known advisory in OSV database
+	FindingVulnerability FindingType = "vulnerability"
+	// FindingInconsistentVersion the module is required at a different version by other go.mod files of the repository
+	FindingInconsistentVersion FindingType = "inconsistent-version"
+	// FindingNewerRelease a newer release is available on the allowed branch that module pinned on
+	FindingNewerRelease FindingType = "newer-release"
+	// FindingDirectiveInvalid the suppression directive in go.mod is invalid
+	FindingDirectiveInvalid FindingType = "directive-invalid"
+	// FindingDirectiveExpired the suppression directive in go.mod is expired
+	FindingDirectiveExpired FindingType = "directive-expired"
+	// FindingDirectiveUnused the suppression directive in go.mod suppresses nothing
+	FindingDirectiveUnused FindingType = "directive-unused"
+)
+
+var findingDescriptions = map[FindingType]string{
+	FindingBranchNotAllowed:      "none of the branches that contain the version is allowed",
+	FindingAnalysisError:         "analysis of the module failed",
+	FindingBranchNotFound:        "not found any branch that contains the version",
+	FindingPullRequestOnly:       "commit is only reachable from pull requests or merge requests",
+	FindingUnreachableCommit:     "commit is not reachable from any branch or pull request of the repository",
+	FindingMergedEquivalent:      "an equivalent commit is merged on the allowed branch",
+	FindingUpstreamPullRequest:   "an upstream pull request contains the commit",
+	FindingCINotPassed:           "required ci checks of the commit are not succeeded",
+	FindingUnsigned:              "neither the pinned commit nor the tag is signed",
+	FindingUntrustedSignature:    "signature of the pinned commit or tag is not trusted",
+	FindingGoVersionIncompatible: "go directive of dependency is newer than ours",
+	FindingModulePathMismatch:    "module path in go.mod of dependency is different from the required path",
+	FindingIgnoredReplace:        "replace directives in go.mod of dependency are ignored by consumers",
+	FindingRetracted:             "the required version is retracted",
+	FindingDeprecated:            "module is deprecated",
+	FindingDenied:                "the version or commit is in the deny list",
+	FindingVulnerability:         "the version is affected by a known vulnerability",
+	FindingInconsistentVersion:   "the module is required at different versions across go.mod files",
+	FindingNewerRelease:          "a newer release is available on the allowed branch",
+	FindingDirectiveInvalid:      "the suppression directive is invalid",
+	FindingDirectiveExpired:      "the suppression directive is expired",
+	FindingDirectiveUnused:       "the suppression directive suppresses nothing",
+}
+
+// Suppressible returns false if the finding type could not be suppressed by directive or baseline,
+// denied versions and known vulnerabilities must always be fixed
+func (t FindingType) Suppressible() bool {
+	return t != FindingDenied && t != FindingVulnerability
+}
+
+// Description returns short description of the finding type
+func (t FindingType) Description() string {
+	if description, ok := findingDescriptions[t]; ok {
+		return description
+	}
+	return string(t)
+}
+
+// Finding is a problem found on a module require besides its branches,
+// it will be reported and commented on the line of the require
+type Finding struct {
+	Type     FindingType
+	Severity Severity
+	Message  string
+	// Key stable identity of the finding among findings of the same type on a module, eg. id of advisory,
+	// it is optional and used for fingerprints of reports instead of the message that may change between runs
+	Key string
+	// SuggestedVersion version that could fix the finding, it is optional
+	SuggestedVersion string
+	// SuppressedBy source that suppressed the finding, eg. baseline or directive, empty means it is not suppressed
+	SuppressedBy string
+}
+
+// branchFindings returns findings when the version is not on any allowed branch
+func branchFindings(ctx context.Context, require ModRequireAnalysis, allowedBranchesRegex string) ([]Finding, error) {
+	if len(require.Branches) == 0 {
+		return nil, nil
+	}
+
+	matched, err := BranchMatched(ctx, require, allowedBranchesRegex)
+	if err != nil || matched {
+		return nil, err
+	}
+
+	return []Finding{
+		{
+			Type:     FindingBranchNotAllowed,
+			Severity: SeverityError,
+			Message:  "branch is " + strings.Join(require.Branches, ","),
+		},
+	}, nil
+}
+
+// hasUnmergedFinding returns true if the version is not reachable from any allowed branch
+func hasUnmergedFinding(require ModRequireAnalysis) bool {
+	for _, finding := range require.Findings {
+		if isUnmergedFinding(finding.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// isUnmergedFinding returns true if the finding type means the commit is not merged on any allowed branch
+func isUnmergedFinding(findingType FindingType) bool {
+	switch findingType {
+	case FindingBranchNotAllowed, FindingPullRequestOnly, FindingUnreachableCommit:
+		return true
+	}
+	return false
+}
@@ -5,20 +5,68 @@ import (
 	"context"
 	"fmt"
 	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
 	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
+	pkgscm "gomod.alauda.cn/gomod-version-lint/pkg/scm"
 	"io"
 	"os"
 	"os/exec"
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 )
 
 type ModRequireAnalysis struct {
 	modfile.Require
+	// ModFile path of go.mod file that requires the module
+	ModFile string
+	// Transitive the module is not required by go.mod files but by other matched modules, eg. analysed for graph
+	Transitive bool
 
+	// Commit full hash of the commit that the version resolved to, it is empty if the repository is not analysed
+	Commit   string
 	Branches []string
-	Error    error
+	Findings []Finding
+	// PullRequests open or merged upstream pull requests whose head branch contains the version
+	PullRequests []pkgscm.PullRequest
+	// CommitStatuses ci statuses of the version, they are only checked when ci is required
+	CommitStatuses []pkgscm.CommitStatus
+	// Signer identity of the signer of the version, it is only verified when signature verification is enabled
+	Signer string
+	Error  *AnalysisError
+	// Requires requires in go.mod of the module at the pinned revision, it is empty if the go.mod is not found
+	Requires []module.Version
+	// Duration time spent on fetching and analysing the module
+	Duration time.Duration
+}
+
+// AnalysisOptions options for BranchAnalysis
+type AnalysisOptions struct {
+	// Concurrency count of modules analysis at the same time
+	Concurrency int8
+	// AllowedBranchesRegex branches that modules are allowed to be pinned on
+	AllowedBranchesRegex string
+	// ErrorPolicy severity of findings for each class of analysis error
+	ErrorPolicy ErrorPolicy
+	// Timeout timeout of analysis for each module, zero means no timeout
+	Timeout time.Duration
+	// Token private access token of git server api, it is optional
+	Token string
+	// PendingUntilMerged marks findings of upstream pull requests as pending until they are merged
+	PendingUntilMerged bool
+	// RequireCI requires ci checks of the commit to be succeeded
+	RequireCI bool
+	// RequiredChecks names of ci contexts that are required, all reported contexts are required if it is empty
+	RequiredChecks []string
+	// Signature options of signature verification
+	Signature SignatureOptions
+	// GoVersion go directive of our go.mod, go directive of dependency should not be newer than it
+	GoVersion string
+	// Proxy options of go module proxy that used to fetch the latest go.mod of module
+	Proxy ProxyOptions
+
+	statusCache *commitStatusCache
 }
 
 func ExcludeBranches(ctx context.Context, require []ModRequireAnalysis, branchExcludeRegex string) ([]ModRequireAnalysis, error) {
@@ -45,33 +93,39 @@ func BranchMatched(ctx context.Context, require ModRequireAnalysis, branchExclud
 		return true, nil
 	}
 
-	regex := branchExcludeRegex
-	if !strings.HasSuffix(regex, "$") {
-		regex = regex + "$"
-	}
-	if !strings.HasPrefix(regex, "^") {
-		regex = "^" + regex
+	branches, err := MatchedBranches(require.Branches, branchExcludeRegex)
+	if err != nil {
+		return false, err
 	}
 
-	r, err := regexp.Compile(regex)
+	return len(branches) > 0, nil
+}
+
+// MatchedBranches returns branches that matched the regex
+func MatchedBranches(branches []string, branchRegex string) ([]string, error) {
+	r, err := regexp.Compile(AnchorRegex(branchRegex))
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	for _, branch := range require.Branches {
-		matched := r.MatchString(branch)
-		if matched {
-			return true, nil
+	matched := []string{}
+	for _, branch := range branches {
+		if r.MatchString(branch) {
+			matched = append(matched, branch)
 		}
 	}
 
-	return false, nil
+	return matched, nil
 }
 
-func BranchAnalysis(ctx context.Context, modules []modfile.Require, concurrency int8) (require []ModRequireAnalysis) {
+func BranchAnalysis(ctx context.Context, modules []modfile.Require, opts AnalysisOptions) (require []ModRequireAnalysis) {
 	logger := pkgctx.GetLogger(ctx)
 
-	threshold := make(chan struct{}, concurrency)
+	if opts.statusCache == nil {
+		opts.statusCache = newCommitStatusCache()
+	}
+
+	threshold := make(chan struct{}, opts.Concurrency)
 	wg := sync.WaitGroup{}
 	require = []ModRequireAnalysis{}
 	requireLock := sync.RWMutex{}
@@ -91,18 +145,67 @@ func BranchAnalysis(ctx context.Context, modules []modfile.Require, concurrency
 				wg.Done()
 			}()
 
+			analysis := ModRequireAnalysis{
+				Require: module,
+			}
+			start := time.Now()
+
+			moduleCtx := ctx
+			if opts.Timeout > 0 {
+				var cancel context.CancelFunc
+				moduleCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+				defer cancel()
+			}
+
 			// TODO: support go proxy
-			branches, _err := branchContains(ctx, "https://"+module.Mod.Path, version)
-			if _err != nil {
-				logger.Errorw("branch contains error", "module", module.Mod.Path, "version", module.Mod.Version, "err", _err)
+			var dir string
+			analysis.Error = checkVCS(module.Mod.Path)
+			if analysis.Error == nil {
+				dir, analysis.Error = cloneRepo(moduleCtx, "https://"+module.Mod.Path)
+			}
+			if analysis.Error == nil {
+				analysis.Commit = resolveCommit(moduleCtx, dir, module.Mod.Path, version)
+				analysis.Branches, analysis.Error = branchContains(moduleCtx, dir, version)
+			}
+			unmergedAnalysis(moduleCtx, dir, &analysis, version, opts)
+
+			if analysis.Error != nil {
+				logger.Errorw("branch contains error", "module", module.Mod.Path, "version", module.Mod.Version, "err", analysis.Error)
+				analysis.Findings = append(analysis.Findings, errorFindings(analysis.Error, opts.ErrorPolicy)...)
+			} else {
+				findings, _err := branchFindings(ctx, analysis, opts.AllowedBranchesRegex)
+				if _err != nil {
+					logger.Errorw("match allowed branches error", "module", module.Mod.Path, "err", _err)
+				}
+				analysis.Findings = append(analysis.Findings, findings...)
+				analysis.Findings = append(analysis.Findings, releaseFindings(moduleCtx, dir, analysis, opts)...)
+				if hasUnmergedFinding(analysis) {
+					analysis.Findings = append(analysis.Findings, equivalentFindings(moduleCtx, dir, analysis, version, opts)...)
+
+					prs, findings := pullRequestFindings(moduleCtx, dir, analysis, version, opts)
+					analysis.PullRequests = prs
+					analysis.Findings = append(analysis.Findings, findings...)
+					if opts.PendingUntilMerged {
+						markPending(analysis.Findings, prs)
+					}
+				}
+
+				statuses, findings := ciFindings(moduleCtx, dir, analysis, version, opts)
+				analysis.CommitStatuses = statuses
+				analysis.Findings = append(analysis.Findings, findings...)
+
+				signer, findings := signatureFindings(moduleCtx, dir, analysis, version, opts.Signature)
+				analysis.Signer = signer
+				analysis.Findings = append(analysis.Findings, findings...)
+				requires, findings := depModFindings(moduleCtx, dir, analysis, version, opts)
+				analysis.Requires = requires
+				analysis.Findings = append(analysis.Findings, findings...)
+				analysis.Findings = append(analysis.Findings, retractFindings(moduleCtx, dir, analysis, opts.Proxy)...)
 			}
 
+			analysis.Duration = time.Since(start)
 			requireLock.Lock()
-			require = append(require, ModRequireAnalysis{
-				Require:  module,
-				Branches: branches,
-				Error:    _err,
-			})
+			require = append(require, analysis)
 			requireLock.Unlock()
 		}()
 	}
@@ -111,7 +214,25 @@ func BranchAnalysis(ctx context.Context, modules []modfile.Require, concurrency
 	return require
 }
 
-func branchContains(ctx context.Context, repoUrl string, commitID string) ([]string, error) {
+// resolveCommit returns full hash of the commit that rev points to, tags of the module in sub directory are preferred,
+// it returns empty if rev is not found in the repository
+func resolveCommit(ctx context.Context, dir string, modulePath string, rev string) string {
+	candidates := []string{rev}
+	if subDir := moduleSubDir(modulePath); subDir != "" {
+		candidates = append([]string{subDir + "/" + rev}, candidates...)
+	}
+
+	for _, candidate := range candidates {
+		stdout, _, err := runCmd(ctx, dir, "git", "rev-parse", "--verify", "-q", candidate+"^{commit}")
+		if err == nil {
+			return strings.TrimSpace(stdout)
+		}
+	}
+	return ""
+}
+
+// cloneRepo clones repository without blobs and checkout into a temp dir and return the dir
+func cloneRepo(ctx context.Context, repoUrl string) (string, *AnalysisError) {
 	logger := pkgctx.GetLogger(ctx)
 
 	dir := encodeRepoUrl(repoUrl)
@@ -119,7 +240,7 @@ func branchContains(ctx context.Context, repoUrl string, commitID string) ([]str
 	tmp, err := os.MkdirTemp("/tmp", dir)
 	if err != nil {
 		logger.Errorf("mk temp dir error: %s", err.Error())
-		return nil, err
+		return "", &AnalysisError{Class: ErrUnknown, Message: err.Error(), Err: err}
 	}
 
 	args := []string{
@@ -130,12 +251,16 @@ func branchContains(ctx context.Context, repoUrl string, commitID string) ([]str
 		"./",
 	}
 
-	stdout, _, err := runCmd(ctx, tmp, "git", args...)
+	_, stderr, err := runCmd(ctx, tmp, "git", args...)
 	if err != nil {
-		return nil, err
+		return "", classifyGitError(ctx, err, stderr)
 	}
 
-	stdout, _, err = runCmd(ctx, tmp, "git", []string{
+	return tmp, nil
+}
+
+func branchContains(ctx context.Context, dir string, commitID string) ([]string, *AnalysisError) {
+	stdout, stderr, err := runCmd(ctx, dir, "git", []string{
 		"branch",
 		"-q",
 		"-r",
@@ -144,10 +269,17 @@ func branchContains(ctx context.Context, repoUrl string, commitID string) ([]str
 	}...)
 
 	if err != nil {
-		return nil, err
+		return nil, classifyGitError(ctx, err, stderr)
 	}
 
-	return parseStdoutOfBranchContains(stdout), nil
+	branches := parseStdoutOfBranchContains(stdout)
+	if len(branches) == 0 {
+		return nil, &AnalysisError{
+			Class:   ErrCommitUnreachable,
+			Message: fmt.Sprintf("not found any branch that contains %s", commitID),
+		}
+	}
+	return branches, nil
 }
 
 func parseStdoutOfBranchContains(stdout string) []string {
@@ -176,7 +308,23 @@ func parseStdoutOfBranchContains(stdout string) []string {
 	return branches
 }
 
+// maxLoggedOutput max bytes of command output that logged, outputs like patches could be very large
+const maxLoggedOutput = 4096
+
+// truncateOutput returns output of command for logging, it is truncated if it is longer than maxLoggedOutput
+func truncateOutput(output []byte) string {
+	if len(output) <= maxLoggedOutput {
+		return string(output)
+	}
+	return fmt.Sprintf("%s... (%d bytes truncated)", output[:maxLoggedOutput], len(output)-maxLoggedOutput)
+}
+
 func runCmd(ctx context.Context, workdir, name string, args ...string) (stdout string, stderr string, err error) {
+	return runCmdWith(ctx, workdir, nil, nil, name, args...)
+}
+
+// runCmdWith runs command with stdin read from input and extra environment variables
+func runCmdWith(ctx context.Context, workdir string, input io.Reader, env []string, name string, args ...string) (stdout string, stderr string, err error) {
 	logger := pkgctx.GetLogger(ctx)
 
 	cmdStr := name + " " + strings.Join(args, " ")
@@ -189,12 +337,14 @@ func runCmd(ctx context.Context, workdir, name string, args ...string) (stdout s
 		"http_proxy=" + os.Getenv("http_proxy"),
 		"all_proxy=" + os.Getenv("all_proxy"),
 	}
+	cmd.Env = append(cmd.Env, env...)
 
 	cmd.Dir = workdir
+	cmd.Stdin = input
 	stdoutBf := bytes.NewBufferString("")
 	stderrBf := bytes.NewBufferString("")
-	cmd.Stdout = io.MultiWriter(os.Stdout, stdoutBf)
-	cmd.Stderr = io.MultiWriter(os.Stderr, stderrBf)
+	cmd.Stdout = stdoutBf
+	cmd.Stderr = stderrBf
 	err = cmd.Run()
 
 	if ctx.Err() != nil {
@@ -214,6 +364,9 @@ func runCmd(ctx context.Context, workdir, name string, args ...string) (stdout s
 		logger.Errorf("read com commd %s stdout error %s", cmdStr, _err)
 	}
 
+	// output of commands is only logged, stdout is kept clean for the output of lint
+	logger.Debugw("command output", "cmd", cmdStr, "stdout", truncateOutput(stdoutBts), "stderr", truncateOutput(stderrBts))
+
 	return string(stdoutBts), string(stderrBts), err
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"golang.org/x/mod/modfile"
 	"golang.org/x/mod/module"
+	"strings"
 	"testing"
 )
 
@@ -67,3 +68,18 @@ func TestExcludeBranches(t *testing.T) {
 		t.Errorf(`modrequire after exclude branch should return branches:[]{"feat/test1", "feat/test2"}, but: %#v`, res[0].Branches)
 	}
 }
+
+func TestTruncateOutput(t *testing.T) {
+	if actual := truncateOutput([]byte("short")); actual != "short" {
+		t.Errorf("short output should not be truncated, but: %s", actual)
+	}
+
+	output := make([]byte, maxLoggedOutput+10)
+	for i := range output {
+		output[i] = 'x'
+	}
+	actual := truncateOutput(output)
+	if strings.Count(actual, "x") != maxLoggedOutput || !strings.HasSuffix(actual, "... (10 bytes truncated)") {
+		t.Errorf("large output should be truncated to %d bytes, but: %s", maxLoggedOutput, actual[maxLoggedOutput:])
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"golang.org/x/mod/modfile"
+	iofs "io/fs"
 	"regexp"
 	"strings"
 )
@@ -15,19 +16,26 @@ func ParseModFile(modFilePath string, bts []byte) (*modfile.File, error) {
 	return file, err
 }
 
+// AnchorRegex anchors the regex of modules so that it matches the whole module path, empty regex is kept empty
+func AnchorRegex(regex string) string {
+	if regex == "" {
+		return regex
+	}
+	if !strings.HasPrefix(regex, "^") {
+		regex = "^" + regex
+	}
+	if !strings.HasSuffix(regex, "$") {
+		regex = regex + "$"
+	}
+	return regex
+}
+
 func MatchModules(ctx context.Context, file *modfile.File, modulesRegex string) (requires []modfile.Require, err error) {
 	if file == nil {
 		return nil, errors.New("modfile should not be nil")
 	}
 
-	if modulesRegex != "" {
-		if !strings.HasPrefix(modulesRegex, "^") {
-			modulesRegex = "^" + modulesRegex
-		}
-		if !strings.HasSuffix(modulesRegex, "$") {
-			modulesRegex = modulesRegex + "$"
-		}
-	}
+	modulesRegex = AnchorRegex(modulesRegex)
 
 	reg, err := regexp.Compile(modulesRegex)
 	if err != nil {
@@ -49,3 +57,26 @@ func MatchModules(ctx context.Context, file *modfile.File, modulesRegex string)
 
 	return matchedRequires, nil
 }
+
+// FindModFiles returns paths of go.mod files in fsys recursively, directories that are ignored by go command
+// like vendor, testdata and the ones beginning with "." or "_" are skipped
+func FindModFiles(fsys iofs.FS) ([]string, error) {
+	files := []string{}
+	err := iofs.WalkDir(fsys, ".", func(path string, d iofs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != "." && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return iofs.SkipDir
+			}
+			return nil
+		}
+		if d.Name() == "go.mod" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
@@ -2,7 +2,9 @@ package pkg
 
 import (
 	"context"
+	"reflect"
 	"testing"
+	"testing/fstest"
 )
 
 var modfileString = `
@@ -46,3 +48,41 @@ func TestMatchModules(t *testing.T) {
 		return
 	}
 }
+
+func TestFindModFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod":                 {},
+		"tools/go.mod":           {},
+		"tools/cmd/go.mod":       {},
+		"vendor/a/go.mod":        {},
+		"pkg/testdata/go.mod":    {},
+		".cache/go.mod":          {},
+		"_example/go.mod":        {},
+		"pkg/lint/go.mod.backup": {},
+	}
+
+	files, err := FindModFiles(fsys)
+	if err != nil {
+		t.Errorf("should find go.mod files correctly, but error: %s", err.Error())
+		return
+	}
+
+	expected := []string{"go.mod", "tools/cmd/go.mod", "tools/go.mod"}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("go.mod files should be %v, but: %v", expected, files)
+	}
+}
+
+func TestAnchorRegex(t *testing.T) {
+	cases := map[string]string{
+		"":                    "",
+		"github.com/demo/.*":  "^github.com/demo/.*$",
+		"^github.com/demo/.*": "^github.com/demo/.*$",
+		"github.com/demo$":    "^github.com/demo$",
+	}
+	for regex, expected := range cases {
+		if actual := AnchorRegex(regex); actual != expected {
+			t.Errorf("anchored regex of %q should be %q, but: %q", regex, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package pkg
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"golang.org/x/mod/semver"
+	"io"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// OSVEntry advisory in OSV format, only fields that used to match go modules are parsed,
+// see https://ossf.github.io/osv-schema/
+type OSVEntry struct {
+	ID       string        `json:"id"`
+	Aliases  []string      `json:"aliases"`
+	Summary  string        `json:"summary"`
+	Affected []OSVAffected `json:"affected"`
+}
+
+type OSVAffected struct {
+	Package struct {
+		Ecosystem string `json:"ecosystem"`
+		Name      string `json:"name"`
+	} `json:"package"`
+	Ranges   []OSVRange `json:"ranges"`
+	Versions []string   `json:"versions"`
+}
+
+type OSVRange struct {
+	Type   string     `json:"type"`
+	Events []OSVEvent `json:"events"`
+}
+
+type OSVEvent struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+}
+
+// OSVDatabase advisories of go modules indexed by module path
+type OSVDatabase struct {
+	entries map[string][]OSVEntry
+}
+
+// LoadOSVDatabase loads OSV json files from a local directory or zip file, eg. Go/all.zip of osv.dev
+func LoadOSVDatabase(path string) (*OSVDatabase, error) {
+	db := &OSVDatabase{entries: map[string][]OSVEntry{}}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() && strings.HasSuffix(path, ".zip") {
+		reader, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return db, db.loadFS(reader)
+	}
+
+	if !info.IsDir() {
+		bts, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return db, db.add(path, bts)
+	}
+
+	return db, db.loadFS(os.DirFS(path))
+}
+
+func (db *OSVDatabase) loadFS(fsys iofs.FS) error {
+	return iofs.WalkDir(fsys, ".", func(path string, d iofs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		file, err := fsys.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		bts, err := io.ReadAll(file)
+		if err != nil {
+			return err
+		}
+		return db.add(path, bts)
+	})
+}
+
+func (db *OSVDatabase) add(path string, bts []byte) error {
+	entry := OSVEntry{}
+	if err := json.Unmarshal(bts, &entry); err != nil {
+		return fmt.Errorf("parse osv file %s error: %s", path, err.Error())
+	}
+
+	for _, affected := range entry.Affected {
+		if affected.Package.Ecosystem != "Go" {
+			continue
+		}
+		db.entries[affected.Package.Name] = append(db.entries[affected.Package.Name], entry)
+	}
+	return nil
+}
+
+// Vulnerabilities returns advisories that affect the module version, and the lowest fixed version of each advisory
+func (db *OSVDatabase) Vulnerabilities(modulePath string, version string) (entries []OSVEntry, fixed []string) {
+	seen := map[string]bool{}
+	for _, entry := range db.entries[modulePath] {
+		if seen[entry.ID] {
+			continue
+		}
+
+		for _, affected := range entry.Affected {
+			if affected.Package.Ecosystem != "Go" || affected.Package.Name != modulePath {
+				continue
+			}
+
+			if ok, fixedVersion := affected.affects(version); ok {
+				seen[entry.ID] = true
+				entries = append(entries, entry)
+				fixed = append(fixed, fixedVersion)
+				break
+			}
+		}
+	}
+	return entries, fixed
+}
+
+// affects returns true if version is affected, and the fixed version of the range that version is in
+func (affected OSVAffected) affects(version string) (bool, string) {
+	for _, item := range affected.Versions {
+		if semver.Compare(osvSemver(item), version) == 0 {
+			return true, ""
+		}
+	}
+
+	for _, r := range affected.Ranges {
+		if r.Type != "SEMVER" {
+			continue
+		}
+
+		events := make([]OSVEvent, len(r.Events))
+		copy(events, r.Events)
+		sort.SliceStable(events, func(i, j int) bool {
+			return semver.Compare(osvSemver(events[i].version()), osvSemver(events[j].version())) < 0
+		})
+
+		affectedFrom := ""
+		for _, event := range events {
+			switch {
+			case event.Introduced != "":
+				affectedFrom = osvSemver(event.Introduced)
+			case event.Fixed != "" && affectedFrom != "":
+				if semver.Compare(version, affectedFrom) >= 0 && semver.Compare(version, osvSemver(event.Fixed)) < 0 {
+					return true, osvSemver(event.Fixed)
+				}
+				affectedFrom = ""
+			case event.LastAffected != "" && affectedFrom != "":
+				if semver.Compare(version, affectedFrom) >= 0 && semver.Compare(version, osvSemver(event.LastAffected)) <= 0 {
+					return true, ""
+				}
+				affectedFrom = ""
+			}
+		}
+		if affectedFrom != "" && semver.Compare(version, affectedFrom) >= 0 {
+			return true, ""
+		}
+	}
+
+	return false, ""
+}
+
+func (event OSVEvent) version() string {
+	switch {
+	case event.Introduced != "":
+		return event.Introduced
+	case event.Fixed != "":
+		return event.Fixed
+	}
+	return event.LastAffected
+}
+
+// osvSemver converts version in OSV to semver with "v" prefix, "0" means the beginning of all versions
+func osvSemver(version string) string {
+	if version == "0" {
+		return "v0.0.0-0"
+	}
+	if !strings.HasPrefix(version, "v") {
+		return "v" + version
+	}
+	return version
+}
+
+// ApplyOSVDatabase adds findings of advisories that affect the required versions of modules
+func ApplyOSVDatabase(mods []ModRequireAnalysis, db *OSVDatabase) {
+	for i := range mods {
+		entries, fixed := db.Vulnerabilities(mods[i].Mod.Path, mods[i].Mod.Version)
+		for j, entry := range entries {
+			id := entry.ID
+			if len(entry.Aliases) > 0 {
+				id = id + " (" + strings.Join(entry.Aliases, ", ") + ")"
+			}
+			message := "vulnerability " + id
+			if entry.Summary != "" {
+				message = message + ": " + entry.Summary
+			}
+			if fixed[j] != "" {
+				message = message + ", fixed in " + fixed[j]
+			}
+
+			mods[i].Findings = append(mods[i].Findings, Finding{
+				Type:             FindingVulnerability,
+				Severity:         SeverityError,
+				Message:          message,
+				Key:              entry.ID,
+				SuggestedVersion: fixed[j],
+			})
+		}
+	}
+}
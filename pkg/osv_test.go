@@ -0,0 +1,66 @@
+package pkg
+
+import (
+	"archive/zip"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var osvEntryString = `{
+  "id": "GO-2023-1234",
+  "aliases": ["CVE-2023-0001"],
+  "summary": "Panic on crafted input",
+  "affected": [{
+    "package": {"ecosystem": "Go", "name": "github.com/example/demo"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "1.2.4"}, {"introduced": "1.3.0"}, {"fixed": "1.3.2"}]}]
+  }]
+}`
+
+func TestApplyOSVDatabase(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "GO-2023-1234.json"), []byte(osvEntryString), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	zipFile, err := os.Create(filepath.Join(t.TempDir(), "all.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer := zip.NewWriter(zipFile)
+	entry, _ := writer.Create("GO-2023-1234.json")
+	entry.Write([]byte(osvEntryString))
+	writer.Close()
+	zipFile.Close()
+
+	cases := map[module.Version]string{
+		{Path: "github.com/example/demo", Version: "v1.2.3"}:                               "vulnerability GO-2023-1234 (CVE-2023-0001): Panic on crafted input, fixed in v1.2.4",
+		{Path: "github.com/example/demo", Version: "v1.2.4"}:                               "",
+		{Path: "github.com/example/demo", Version: "v1.3.1"}:                               "vulnerability GO-2023-1234 (CVE-2023-0001): Panic on crafted input, fixed in v1.3.2",
+		{Path: "github.com/example/demo", Version: "v1.3.3-0.20230620020346-5e946b016f71"}: "",
+		{Path: "github.com/example/other", Version: "v1.2.3"}:                              "",
+	}
+
+	for _, path := range []string{dir, zipFile.Name()} {
+		db, err := LoadOSVDatabase(path)
+		if err != nil {
+			t.Errorf("should load osv database %s correctly, but error: %s", path, err.Error())
+			continue
+		}
+
+		for version, expected := range cases {
+			mods := []ModRequireAnalysis{{Require: modfile.Require{Mod: version}}}
+			ApplyOSVDatabase(mods, db)
+
+			actual := ""
+			if len(mods[0].Findings) > 0 {
+				actual = mods[0].Findings[0].Message
+			}
+			if actual != expected {
+				t.Errorf("finding of %s in %s should be %q, but: %q", version, path, expected, actual)
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"golang.org/x/mod/module"
+	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
+	pkgscm "gomod.alauda.cn/gomod-version-lint/pkg/scm"
+	"strings"
+)
+
+// PendingSuppression value of Finding.SuppressedBy when the finding is pending until the upstream pull request merges
+const PendingSuppression = "pending-until-merged"
+
+// scmRepository returns git server host, server type and repository path of the module,
+// server type is empty if it is unknown
+func scmRepository(modulePath string) (host string, serverType string, repoPath string) {
+	segments := strings.Split(modulePath, "/")
+	if len(segments) < 3 {
+		return segments[0], "", ""
+	}
+
+	host = segments[0]
+	serverType = pkgscm.DetectServerType(host)
+	if serverType == "github" {
+		return host, serverType, segments[1] + "/" + segments[2]
+	}
+
+	prefix, _, _ := module.SplitPathVersion(modulePath)
+	return host, serverType, strings.TrimPrefix(prefix, host+"/")
+}
+
+// newScmClient creates scm client for the git server of module
+func newScmClient(ctx context.Context, modulePath string, token string) (client pkgscm.Client, repoPath string, err error) {
+	host, serverType, repoPath := scmRepository(modulePath)
+	if serverType == "" {
+		return nil, "", fmt.Errorf("unknown git server type of host %s", host)
+	}
+
+	client, err = pkgscm.NewScmClient(ctx, serverType, "https://"+host, token)
+	return client, repoPath, err
+}
+
+// pullRequestFindings finds open or merged pull requests whose head branch contains the commit of rev
+func pullRequestFindings(ctx context.Context, dir string, require ModRequireAnalysis, rev string, opts AnalysisOptions) ([]pkgscm.PullRequest, []Finding) {
+	logger := pkgctx.GetLogger(ctx).With("module", require.Mod.Path, "rev", rev)
+
+	if !module.IsPseudoVersion(require.Mod.Version) {
+		return nil, nil
+	}
+
+	if require.Commit == "" {
+		logger.Debugw("commit is not resolved, skip searching upstream pull requests")
+		return nil, nil
+	}
+
+	client, repoPath, err := newScmClient(ctx, require.Mod.Path, opts.Token)
+	if err != nil {
+		logger.Debugw("create scm client error", "err", err)
+		return nil, nil
+	}
+
+	prs, err := client.ListPullRequestsWithCommit(ctx, repoPath, require.Commit)
+	if err != nil {
+		logger.Errorw("list pull requests with commit error", "err", err)
+		return nil, nil
+	}
+
+	upstream := []pkgscm.PullRequest{}
+	findings := []Finding{}
+	for _, pr := range prs {
+		if pr.State == pkgscm.PullRequestClosed {
+			continue
+		}
+		upstream = append(upstream, pr)
+
+		name := pullRequestName(require.Mod.Path, pr.ID)
+		approvals := fmt.Sprintf("%d approvals", pr.Approvals)
+		if pr.ApprovalsUnknown {
+			approvals = "unknown approvals"
+		}
+		message := fmt.Sprintf("upstream %s from branch %s is %s with %s: %s", name, pr.HeadBranch, pr.State, approvals, pr.URL)
+		if pr.State == pkgscm.PullRequestOpen && opts.PendingUntilMerged {
+			message = fmt.Sprintf("⏳ pending until upstream %s from branch %s merges, it has %s: %s", name, pr.HeadBranch, approvals, pr.URL)
+		}
+		findings = append(findings, Finding{
+			Type:     FindingUpstreamPullRequest,
+			Severity: SeverityInfo,
+			Message:  message,
+		})
+	}
+
+	return upstream, findings
+}
+
+func pullRequestName(modulePath string, id int) string {
+	if _, serverType, _ := scmRepository(modulePath); serverType == "gitlab" {
+		return fmt.Sprintf("merge request !%d", id)
+	}
+	return fmt.Sprintf("pull request #%d", id)
+}
+
+// markPending suppresses unmerged findings as pending when an upstream pull request of the commit is open,
+// pending findings are not violations, but they are still commented until the pull request merges
+func markPending(findings []Finding, prs []pkgscm.PullRequest) {
+	open := false
+	for _, pr := range prs {
+		if pr.State == pkgscm.PullRequestOpen {
+			open = true
+			break
+		}
+	}
+	if !open {
+		return
+	}
+
+	for i := range findings {
+		if isUnmergedFinding(findings[i].Type) && findings[i].SuppressedBy == "" {
+			findings[i].SuppressedBy = PendingSuppression
+		}
+	}
+}
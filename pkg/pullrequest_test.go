@@ -0,0 +1,50 @@
+package pkg
+
+import (
+	pkgscm "gomod.alauda.cn/gomod-version-lint/pkg/scm"
+	"testing"
+)
+
+func TestScmRepository(t *testing.T) {
+	cases := []struct {
+		modulePath string
+		serverType string
+		repoPath   string
+	}{
+		{modulePath: "github.com/demo/demo", serverType: "github", repoPath: "demo/demo"},
+		{modulePath: "github.com/demo/demo/v2/pkg", serverType: "github", repoPath: "demo/demo"},
+		{modulePath: "gitlab.example.com/group/sub/demo/v2", serverType: "gitlab", repoPath: "group/sub/demo"},
+		{modulePath: "git.example.com/demo/demo", serverType: "", repoPath: "demo/demo"},
+	}
+
+	for _, item := range cases {
+		_, serverType, repoPath := scmRepository(item.modulePath)
+		if serverType != item.serverType || repoPath != item.repoPath {
+			t.Errorf("server type and repository of %s should be %s %s, but: %s %s", item.modulePath, item.serverType, item.repoPath, serverType, repoPath)
+		}
+	}
+
+	if name := pullRequestName("gitlab.example.com/group/demo", 3); name != "merge request !3" {
+		t.Errorf("name of gitlab merge request should be 'merge request !3', but: %s", name)
+	}
+}
+
+func TestMarkPending(t *testing.T) {
+	findings := []Finding{
+		{Type: FindingBranchNotAllowed, Severity: SeverityError},
+		{Type: FindingUpstreamPullRequest, Severity: SeverityInfo},
+	}
+
+	markPending(findings, []pkgscm.PullRequest{{ID: 1, State: pkgscm.PullRequestMerged}})
+	if findings[0].SuppressedBy != "" {
+		t.Errorf("finding should not be pending without open pull request, but: %#v", findings[0])
+	}
+
+	markPending(findings, []pkgscm.PullRequest{{ID: 2, State: pkgscm.PullRequestOpen}})
+	if findings[0].SuppressedBy != PendingSuppression || findings[0].IsViolation(SeverityError) {
+		t.Errorf("unmerged finding should be pending and not a violation, but: %#v", findings[0])
+	}
+	if findings[1].SuppressedBy != "" {
+		t.Errorf("upstream pull request finding should not be pending, but: %#v", findings[1])
+	}
+}
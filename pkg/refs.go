@@ -0,0 +1,126 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"golang.org/x/mod/module"
+	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
+	"strings"
+)
+
+// pullRequestRefspecs refspecs of pull requests on github and merge requests on gitlab,
+// they are not fetched by clone
+var pullRequestRefspecs = []string{
+	"+refs/pull/*/head:refs/remotes/pull/*",
+	"+refs/merge-requests/*/head:refs/remotes/merge-requests/*",
+}
+
+// unmergedAnalysis replaces the commit-not-found error of analysis with findings of unmerged commit,
+// commits that exist in the clone but are unreachable from any branch are kept as errors
+func unmergedAnalysis(ctx context.Context, dir string, analysis *ModRequireAnalysis, rev string, opts AnalysisOptions) {
+	if analysis.Error == nil || analysis.Error.Class != ErrCommitNotFound {
+		return
+	}
+
+	if findings := unmergedFindings(ctx, dir, *analysis, rev, opts); len(findings) > 0 {
+		analysis.Error = nil
+		analysis.Findings = append(analysis.Findings, findings...)
+		if analysis.Commit == "" {
+			// commit is fetched from pull request refs or the repository now
+			analysis.Commit = resolveCommit(ctx, dir, analysis.Mod.Path, rev)
+		}
+	}
+}
+
+// unmergedFindings finds commit that only reachable from pull requests or merge requests, or only served by the repository,
+// it is used when commit of pseudo-version is not reachable from any branch, and returns nil if commit is not found in neither of them
+func unmergedFindings(ctx context.Context, dir string, require ModRequireAnalysis, rev string, opts AnalysisOptions) []Finding {
+	logger := pkgctx.GetLogger(ctx).With("module", require.Mod.Path, "rev", rev)
+
+	if !module.IsPseudoVersion(require.Mod.Version) {
+		return nil
+	}
+
+	args := append([]string{"fetch", "-q", "origin"}, pullRequestRefspecs...)
+	_, _, err := runCmd(ctx, dir, "git", args...)
+	if err != nil {
+		logger.Errorw("fetch pull request refs error", "err", err)
+		return nil
+	}
+
+	stdout, _, err := runCmd(ctx, dir, "git", "for-each-ref", "--contains", rev, "--format=%(refname:lstrip=2)",
+		"refs/remotes/pull", "refs/remotes/merge-requests")
+	if err == nil {
+		if requests := parsePullRequestRefs(stdout); len(requests) > 0 {
+			return []Finding{
+				{
+					Type:     FindingPullRequestOnly,
+					Severity: SeverityError,
+					Message:  "commit is only reachable from " + strings.Join(requests, ", "),
+				},
+			}
+		}
+	}
+
+	if servedCommit(ctx, dir, require.Mod.Path, rev, opts.Token) {
+		return []Finding{
+			{
+				Type:     FindingUnreachableCommit,
+				Severity: SeverityError,
+				Message:  "commit is not reachable from any branch or pull request of the repository, it may only exist in a fork or on a deleted branch",
+			},
+		}
+	}
+	return nil
+}
+
+// parsePullRequestRefs parses output of for-each-ref to names of pull requests or merge requests
+func parsePullRequestRefs(stdout string) []string {
+	requests := []string{}
+	for _, item := range strings.Split(stdout, "\n") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		if id := strings.TrimPrefix(item, "pull/"); id != item {
+			requests = append(requests, "pull request #"+id)
+			continue
+		}
+		if id := strings.TrimPrefix(item, "merge-requests/"); id != item {
+			requests = append(requests, "merge request !"+id)
+		}
+	}
+	return requests
+}
+
+// servedCommit returns true if commit is served by github through the repository but is not reachable from its refs,
+// the commit may only exist in a fork, on a deleted branch or be an unreferenced object that is not collected yet
+func servedCommit(ctx context.Context, dir string, modulePath string, rev string, token string) bool {
+	logger := pkgctx.GetLogger(ctx).With("module", modulePath, "rev", rev)
+
+	if _, serverType, _ := scmRepository(modulePath); serverType != "github" {
+		return false
+	}
+
+	client, repoPath, err := newScmClient(ctx, modulePath, token)
+	if err != nil {
+		logger.Errorw("create scm client error", "err", err)
+		return false
+	}
+
+	sha, err := client.GetCommitSHA(ctx, repoPath, rev)
+	if err != nil {
+		logger.Debugw("get commit sha error", "err", err)
+		return false
+	}
+
+	_, _, err = runCmd(ctx, dir, "git", "fetch", "-q", "origin", sha)
+	if err != nil {
+		logger.Debugw("fetch commit error", "sha", sha, "err", err)
+		return false
+	}
+
+	logger.Infow(fmt.Sprintf("commit %s is only served through the repository", sha))
+	return true
+}
@@ -0,0 +1,88 @@
+package pkg
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestParsePullRequestRefs(t *testing.T) {
+	stdout := `pull/12
+merge-requests/3
+
+pull/15
+`
+
+	requests := parsePullRequestRefs(stdout)
+	expected := []string{"pull request #12", "merge request !3", "pull request #15"}
+	if len(requests) != len(expected) {
+		t.Errorf("pull requests should be %v, but: %v", expected, requests)
+		return
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Errorf("pull request %d should be %s, but: %s", i, expected[i], requests[i])
+		}
+	}
+}
+
+// testGit runs git command in dir for tests and returns trimmed stdout
+func testGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com",
+		"-c", "commit.gpgsign=false", "-c", "init.defaultBranch=main"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s error: %s: %s", strings.Join(args, " "), err.Error(), string(out))
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// testCommit commits an empty commit with message in dir and returns its hash
+func testCommit(t *testing.T, dir string, message string) string {
+	t.Helper()
+	testGit(t, dir, "commit", "-q", "--allow-empty", "-m", message)
+	return testGit(t, dir, "rev-parse", "HEAD")
+}
+
+func TestUnmergedAnalysisUnreachable(t *testing.T) {
+	ctx := pkgctx.WithLogger(context.Background(), zap.NewNop().Sugar())
+
+	origin := t.TempDir()
+	testGit(t, origin, "init", "-q")
+	testCommit(t, origin, "init")
+	// the commit is only referenced by a tag and a pull request, it is fetched by clone but unreachable from branches
+	testGit(t, origin, "checkout", "-q", "-b", "feature")
+	commit := testCommit(t, origin, "feature")
+	testGit(t, origin, "tag", "unreachable")
+	testGit(t, origin, "update-ref", "refs/pull/1/head", commit)
+	testGit(t, origin, "checkout", "-q", "main")
+	testGit(t, origin, "branch", "-q", "-D", "feature")
+
+	dir := t.TempDir()
+	testGit(t, dir, "clone", "-q", origin, ".")
+
+	analysis := ModRequireAnalysis{
+		Require: modfile.Require{
+			Mod: module.Version{Path: "git.example.com/demo/demo", Version: "v0.0.0-20230101000000-" + commit[:12]},
+		},
+	}
+	analysis.Branches, analysis.Error = branchContains(ctx, dir, commit[:12])
+	if analysis.Error == nil || analysis.Error.Class != ErrCommitUnreachable {
+		t.Fatalf("commit only referenced by tag should be unreachable, but: %#v", analysis.Error)
+	}
+
+	unmergedAnalysis(ctx, dir, &analysis, commit[:12], AnalysisOptions{})
+	if analysis.Error == nil || analysis.Error.Class != ErrCommitUnreachable {
+		t.Errorf("unreachable commit should be kept as error, but: %#v", analysis.Error)
+	}
+	if len(analysis.Findings) != 0 {
+		t.Errorf("unreachable commit should not be reported as unmerged, but: %#v", analysis.Findings)
+	}
+}
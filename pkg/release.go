@@ -0,0 +1,102 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
+	"strings"
+)
+
+// releaseFindings finds newer patch or minor releases that reachable from the allowed branches
+// which contain the tagged version of the module
+func releaseFindings(ctx context.Context, dir string, require ModRequireAnalysis, opts AnalysisOptions) []Finding {
+	logger := pkgctx.GetLogger(ctx)
+
+	version := require.Mod.Version
+	if module.IsPseudoVersion(version) || opts.AllowedBranchesRegex == "" {
+		return nil
+	}
+
+	branches, err := MatchedBranches(require.Branches, opts.AllowedBranchesRegex)
+	if err != nil {
+		logger.Errorw("match allowed branches error", "module", require.Mod.Path, "err", err)
+		return nil
+	}
+
+	latest, latestBranch := "", ""
+	for _, branch := range branches {
+		tags, err := mergedTags(ctx, dir, require.Mod.Path, branch)
+		if err != nil {
+			logger.Errorw("list tags error", "module", require.Mod.Path, "branch", branch, "err", err)
+			continue
+		}
+
+		newer := newerRelease(version, tags)
+		if newer != "" && (latest == "" || semver.Compare(newer, latest) > 0) {
+			latest, latestBranch = newer, branch
+		}
+	}
+
+	if latest == "" {
+		return nil
+	}
+
+	return []Finding{
+		{
+			Type:             FindingNewerRelease,
+			Severity:         SeverityInfo,
+			Message:          fmt.Sprintf("newer release %s available on your branch %s", latest, latestBranch),
+			SuggestedVersion: latest,
+		},
+	}
+}
+
+// mergedTags lists semver tags of the module that reachable from the remote branch,
+// tags of module in sub directory are prefixed by the sub directory, the prefix is trimmed
+func mergedTags(ctx context.Context, dir string, modulePath string, branch string) ([]string, error) {
+	prefix := moduleSubDir(modulePath)
+	if prefix != "" {
+		prefix = prefix + "/"
+	}
+
+	stdout, _, err := runCmd(ctx, dir, "git", "tag", "--list", prefix+"v*", "--merged", "origin/"+branch)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := []string{}
+	for _, item := range strings.Split(stdout, "\n") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		tags = append(tags, strings.TrimPrefix(item, prefix))
+	}
+	return tags, nil
+}
+
+// newerRelease returns the newest release in tags which has the same major with version,
+// pre-releases are ignored, it returns empty if there is no newer release
+func newerRelease(version string, tags []string) string {
+	version = strings.TrimSuffix(version, "+incompatible")
+	if !semver.IsValid(version) {
+		return ""
+	}
+
+	newest := ""
+	for _, tag := range tags {
+		if !semver.IsValid(tag) || semver.Prerelease(tag) != "" || semver.Build(tag) != "" {
+			continue
+		}
+		if semver.Major(tag) != semver.Major(version) || semver.Compare(tag, version) <= 0 {
+			continue
+		}
+		if newest == "" || semver.Compare(tag, newest) > 0 {
+			newest = tag
+		}
+	}
+
+	return newest
+}
@@ -0,0 +1,58 @@
+package pkg
+
+import (
+	"context"
+	"go.uber.org/zap"
+	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
+	"strings"
+	"testing"
+)
+
+func TestNewerRelease(t *testing.T) {
+	tags := []string{"v0.6.0", "v0.7.0", "v0.7.1", "v0.7.3", "v0.8.0-rc.1", "v1.0.0", "not-semver"}
+
+	cases := []struct {
+		version  string
+		expected string
+	}{
+		{version: "v0.7.0", expected: "v0.7.3"},
+		{version: "v0.7.3", expected: ""},
+		{version: "v0.5.0", expected: "v0.7.3"},
+		{version: "v1.0.0", expected: ""},
+		{version: "v2.0.0+incompatible", expected: ""},
+	}
+
+	for _, item := range cases {
+		actual := newerRelease(item.version, tags)
+		if actual != item.expected {
+			t.Errorf("newer release of %s should be %q, but: %q", item.version, item.expected, actual)
+		}
+	}
+}
+
+func TestMergedTags(t *testing.T) {
+	ctx := pkgctx.WithLogger(context.Background(), zap.NewNop().Sugar())
+
+	dir := t.TempDir()
+	testGit(t, dir, "init", "-q")
+	testCommit(t, dir, "init")
+	for _, tag := range []string{"v1.0.0", "v1.3.0", "tools/v1.0.1", "tools/v1.2.0"} {
+		testGit(t, dir, "tag", tag)
+	}
+	testGit(t, dir, "update-ref", "refs/remotes/origin/main", "HEAD")
+
+	cases := map[string]string{
+		"github.com/example/demo":       "v1.0.0,v1.3.0",
+		"github.com/example/demo/tools": "v1.0.1,v1.2.0",
+	}
+	for modulePath, expected := range cases {
+		tags, err := mergedTags(ctx, dir, modulePath, "main")
+		if err != nil {
+			t.Errorf("should list tags of %s, but error: %s", modulePath, err.Error())
+			continue
+		}
+		if actual := strings.Join(tags, ","); actual != expected {
+			t.Errorf("tags of %s should be %s, but: %s", modulePath, expected, actual)
+		}
+	}
+}
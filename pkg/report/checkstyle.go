@@ -0,0 +1,69 @@
+package report
+
+import (
+	"encoding/xml"
+	"gomod.alauda.cn/gomod-version-lint/pkg"
+	"io"
+)
+
+// checkstyleReporter reports findings in Checkstyle XML, suppressed findings are not reported,
+// the source of error is the finding type, so the error is identified by file, line and source
+type checkstyleReporter struct {
+	opts Options
+}
+
+type checkstyleResult struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+func (r *checkstyleReporter) Report(writer io.Writer, mods []pkg.ModRequireAnalysis) error {
+	result := checkstyleResult{Version: "8.0"}
+	fileIndex := map[string]int{}
+
+	for _, item := range mods {
+		for _, finding := range item.Findings {
+			if finding.SuppressedBy != "" {
+				continue
+			}
+
+			file := modFile(item, r.opts)
+			if _, ok := fileIndex[file]; !ok {
+				fileIndex[file] = len(result.Files)
+				result.Files = append(result.Files, checkstyleFile{Name: file})
+			}
+			result.Files[fileIndex[file]].Errors = append(result.Files[fileIndex[file]].Errors, checkstyleError{
+				Line:     line(item),
+				Column:   1,
+				Severity: string(finding.Severity),
+				Message:  findingDescription(item, finding),
+				Source:   "gomod-version-lint." + string(finding.Type),
+			})
+		}
+	}
+
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return err
+	}
+	_, err := io.WriteString(writer, "\n")
+	return err
+}
@@ -0,0 +1,35 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+)
+
+func TestCheckstyleReporter(t *testing.T) {
+	reporter, err := New("checkstyle", Options{})
+	if err != nil {
+		t.Errorf("should create checkstyle reporter, but error: %s", err.Error())
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	if err := reporter.Report(buf, testMods); err != nil {
+		t.Errorf("should report correctly, but error: %s", err.Error())
+		return
+	}
+
+	result := checkstyleResult{}
+	if err := xml.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Errorf("should be valid xml, but error: %s", err.Error())
+		return
+	}
+
+	if len(result.Files) != 1 || result.Files[0].Name != "go.mod" || len(result.Files[0].Errors) != 1 {
+		t.Errorf("should report one error in go.mod, but: %s", buf.String())
+		return
+	}
+	if e := result.Files[0].Errors[0]; e.Line != 12 || e.Severity != "error" || e.Source != "gomod-version-lint.branch-not-allowed" {
+		t.Errorf("error should be at line 12 with source of finding type, but: %#v", e)
+	}
+}
@@ -0,0 +1,65 @@
+package report
+
+import (
+	"encoding/json"
+	"gomod.alauda.cn/gomod-version-lint/pkg"
+	"io"
+)
+
+// codeQualityReporter reports findings in GitLab Code Quality format, suppressed findings are not reported,
+// see https://docs.gitlab.com/ee/ci/testing/code_quality.html#implement-a-custom-tool
+type codeQualityReporter struct {
+	opts Options
+}
+
+type codeQualityIssue struct {
+	Description string              `json:"description"`
+	CheckName   string              `json:"check_name"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    codeQualityLocation `json:"location"`
+}
+
+type codeQualityLocation struct {
+	Path  string           `json:"path"`
+	Lines codeQualityLines `json:"lines"`
+}
+
+type codeQualityLines struct {
+	Begin int `json:"begin"`
+}
+
+func (r *codeQualityReporter) Report(writer io.Writer, mods []pkg.ModRequireAnalysis) error {
+	issues := []codeQualityIssue{}
+	for _, item := range mods {
+		for _, finding := range item.Findings {
+			if finding.SuppressedBy != "" {
+				continue
+			}
+			issues = append(issues, codeQualityIssue{
+				Description: findingDescription(item, finding),
+				CheckName:   string(finding.Type),
+				Fingerprint: fingerprint(item, finding, r.opts),
+				Severity:    codeQualitySeverity(finding.Severity),
+				Location: codeQualityLocation{
+					Path:  modFile(item, r.opts),
+					Lines: codeQualityLines{Begin: line(item)},
+				},
+			})
+		}
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(issues)
+}
+
+func codeQualitySeverity(severity pkg.Severity) string {
+	switch severity {
+	case pkg.SeverityError:
+		return "major"
+	case pkg.SeverityWarning:
+		return "minor"
+	}
+	return "info"
+}
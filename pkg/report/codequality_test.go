@@ -0,0 +1,70 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"gomod.alauda.cn/gomod-version-lint/pkg"
+	"testing"
+)
+
+func TestCodeQualityReporter(t *testing.T) {
+	reporter, err := New("codequality", Options{})
+	if err != nil {
+		t.Errorf("should create code quality reporter, but error: %s", err.Error())
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	if err := reporter.Report(buf, testMods); err != nil {
+		t.Errorf("should report correctly, but error: %s", err.Error())
+		return
+	}
+
+	issues := []codeQualityIssue{}
+	if err := json.Unmarshal(buf.Bytes(), &issues); err != nil {
+		t.Errorf("should be valid json, but error: %s", err.Error())
+		return
+	}
+
+	if len(issues) != 1 {
+		t.Errorf("suppressed findings should not be reported, but: %s", buf.String())
+		return
+	}
+	expected := codeQualityIssue{
+		Description: "github.com/example/demo@v0.7.1-0.20230620020346-5e946b016f71: branches feature-x are not allowed (branches: feature-x)",
+		CheckName:   "branch-not-allowed",
+		Fingerprint: fingerprint(testMods[0], testMods[0].Findings[0], Options{}),
+		Severity:    "major",
+		Location:    codeQualityLocation{Path: "go.mod", Lines: codeQualityLines{Begin: 12}},
+	}
+	if issues[0] != expected {
+		t.Errorf("issue should be %#v, but: %#v", expected, issues[0])
+	}
+}
+
+func TestFingerprintSameType(t *testing.T) {
+	item := pkg.ModRequireAnalysis{
+		Require: modfile.Require{Mod: module.Version{Path: "github.com/example/demo", Version: "v1.0.0"}},
+		Findings: []pkg.Finding{
+			{Type: pkg.FindingVulnerability, Severity: pkg.SeverityError, Message: "vulnerability GO-2023-0001", Key: "GO-2023-0001"},
+			{Type: pkg.FindingVulnerability, Severity: pkg.SeverityError, Message: "vulnerability GO-2023-0002", Key: "GO-2023-0002"},
+		},
+	}
+
+	first := fingerprint(item, item.Findings[0], Options{})
+	if first == fingerprint(item, item.Findings[1], Options{}) {
+		t.Errorf("fingerprints of findings with the same type should be different, but both are: %s", first)
+	}
+
+	item.Syntax = &modfile.Line{Start: modfile.Position{Line: 20}}
+	if first != fingerprint(item, item.Findings[0], Options{}) {
+		t.Errorf("fingerprint should not change when the line of require changes")
+	}
+
+	item.Findings[0].Message = "vulnerability GO-2023-0001, fixed in v1.0.1"
+	if first != fingerprint(item, item.Findings[0], Options{}) {
+		t.Errorf("fingerprint should not change when the message changes")
+	}
+}
@@ -0,0 +1,55 @@
+package report
+
+import (
+	"fmt"
+	"gomod.alauda.cn/gomod-version-lint/pkg"
+	"io"
+	"strings"
+)
+
+// githubReporter reports findings as workflow commands of GitHub Actions, so they are annotated on the line of go.mod,
+// suppressed findings are not reported,
+// see https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions
+type githubReporter struct {
+	opts Options
+}
+
+func (r *githubReporter) Report(writer io.Writer, mods []pkg.ModRequireAnalysis) error {
+	for _, item := range mods {
+		for _, finding := range item.Findings {
+			if finding.SuppressedBy != "" {
+				continue
+			}
+
+			_, err := fmt.Fprintf(writer, "::%s file=%s,line=%d,title=%s::%s\n",
+				githubCommand(finding.Severity),
+				githubProperty(modFile(item, r.opts)),
+				line(item),
+				githubProperty(string(finding.Type)),
+				githubData(findingDescription(item, finding)),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func githubCommand(severity pkg.Severity) string {
+	switch severity {
+	case pkg.SeverityError:
+		return "error"
+	case pkg.SeverityWarning:
+		return "warning"
+	}
+	return "notice"
+}
+
+func githubData(str string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(str)
+}
+
+func githubProperty(str string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(str)
+}
@@ -0,0 +1,30 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestGithubReporter(t *testing.T) {
+	reporter, err := New("github", Options{})
+	if err != nil {
+		t.Errorf("should create github reporter, but error: %s", err.Error())
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	if err := reporter.Report(buf, testMods); err != nil {
+		t.Errorf("should report correctly, but error: %s", err.Error())
+		return
+	}
+
+	expected := "::error file=go.mod,line=12,title=branch-not-allowed::github.com/example/demo@v0.7.1-0.20230620020346-5e946b016f71: " +
+		"branches feature-x are not allowed (branches: feature-x)\n"
+	if buf.String() != expected {
+		t.Errorf("annotations should be %q, but: %q", expected, buf.String())
+	}
+
+	if actual := githubData("50%\nof"); actual != "50%25%0Aof" {
+		t.Errorf("data should be escaped, but: %q", actual)
+	}
+}
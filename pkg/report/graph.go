@@ -0,0 +1,199 @@
+package report
+
+import (
+	"fmt"
+	"gomod.alauda.cn/gomod-version-lint/pkg"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// graphReporter renders matched modules and their internal dependencies as Graphviz DOT or Mermaid,
+// nodes are colored by compliance and edges are labeled with the required version.
+// internal dependencies are the requires of dependencies that matched Options.ModuleRegex,
+// they are known only when go.mod of dependencies are read at the pinned revision.
+// transitive modules are drawn without edges from go.mod files, so the chain of internal modules is kept,
+// internal dependencies that are not analysed have no status
+type graphReporter struct {
+	format string
+	opts   Options
+}
+
+type graphNode struct {
+	ID      string
+	Path    string
+	Version string
+	// Status compliance status of module, it is empty if the module is not analysed
+	Status string
+}
+
+type graphEdge struct {
+	From    string
+	To      string
+	Version string
+}
+
+type graph struct {
+	Nodes []graphNode
+	Edges []graphEdge
+}
+
+var graphColors = map[string][2]string{
+	statusError:      {"#fbefff", "#8250df"},
+	statusViolation:  {"#ffebe9", "#cf222e"},
+	statusWarning:    {"#fff8c5", "#9a6700"},
+	statusSuppressed: {"#f6f8fa", "#57606a"},
+	statusCompliant:  {"#dafbe1", "#1a7f37"},
+	"":               {"#ffffff", "#8c959f"},
+	"root":           {"#ddf4ff", "#0969da"},
+}
+
+func (r *graphReporter) Report(writer io.Writer, mods []pkg.ModRequireAnalysis) error {
+	g, err := r.build(mods)
+	if err != nil {
+		return err
+	}
+
+	if r.format == "mermaid" {
+		return writeMermaid(writer, g)
+	}
+	return writeDot(writer, g)
+}
+
+// build builds graph from go.mod files to matched modules, and from matched modules to their internal requires
+func (r *graphReporter) build(mods []pkg.ModRequireAnalysis) (graph, error) {
+	// all modules are internal if regex is empty
+	internal, err := regexp.Compile(pkg.AnchorRegex(r.opts.ModuleRegex))
+	if err != nil {
+		return graph{}, err
+	}
+
+	g := graph{}
+	nodes := map[string]int{}
+	edges := map[graphEdge]bool{}
+	addNode := func(node graphNode) {
+		if i, ok := nodes[node.ID]; ok {
+			if g.Nodes[i].Status == "" {
+				g.Nodes[i].Status = node.Status
+			}
+			return
+		}
+		nodes[node.ID] = len(g.Nodes)
+		g.Nodes = append(g.Nodes, node)
+	}
+	addEdge := func(edge graphEdge) {
+		if !edges[edge] {
+			edges[edge] = true
+			g.Edges = append(g.Edges, edge)
+		}
+	}
+
+	for _, item := range mods {
+		status, _ := moduleStatus(item, r.opts)
+		id := item.Mod.String()
+		if item.Transitive {
+			addNode(graphNode{ID: id, Path: item.Mod.Path, Version: item.Mod.Version, Status: status})
+			continue
+		}
+
+		file := modFile(item, r.opts)
+		root := file
+		if path, ok := r.opts.ModulePaths[file]; ok && path != "" {
+			root = path
+		}
+		addNode(graphNode{ID: root, Path: root, Status: "root"})
+		addNode(graphNode{ID: id, Path: item.Mod.Path, Version: item.Mod.Version, Status: status})
+		addEdge(graphEdge{From: root, To: id, Version: item.Mod.Version})
+	}
+
+	for _, item := range mods {
+		for _, require := range item.Requires {
+			if !internal.MatchString(require.Path) {
+				continue
+			}
+			addNode(graphNode{ID: require.String(), Path: require.Path, Version: require.Version})
+			addEdge(graphEdge{From: item.Mod.String(), To: require.String(), Version: require.Version})
+		}
+	}
+
+	sort.SliceStable(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+	return g, nil
+}
+
+func writeDot(writer io.Writer, g graph) error {
+	builder := &strings.Builder{}
+	builder.WriteString("digraph gomod {\n")
+	builder.WriteString("  rankdir=LR;\n")
+	builder.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+	builder.WriteString("  edge [fontname=\"Helvetica\", fontsize=10];\n")
+	for _, node := range g.Nodes {
+		label := node.Path
+		if node.Version != "" {
+			label = label + "\\n" + node.Version
+		}
+		colors := graphColors[node.Status]
+		style := ""
+		if node.Status == "" {
+			style = ", style=\"rounded,filled,dashed\""
+		}
+		fmt.Fprintf(builder, "  %q [label=\"%s\", fillcolor=%q, color=%q%s];\n", node.ID, label, colors[0], colors[1], style)
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(builder, "  %q -> %q [label=%q];\n", edge.From, edge.To, edge.Version)
+	}
+	builder.WriteString("}\n")
+
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+func writeMermaid(writer io.Writer, g graph) error {
+	ids := map[string]string{}
+	builder := &strings.Builder{}
+	builder.WriteString("graph LR\n")
+	for i, node := range g.Nodes {
+		ids[node.ID] = fmt.Sprintf("n%d", i)
+		label := node.Path
+		if node.Version != "" {
+			label = label + "<br/>" + node.Version
+		}
+		fmt.Fprintf(builder, "  %s[\"%s\"]\n", ids[node.ID], label)
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(builder, "  %s -->|\"%s\"| %s\n", ids[edge.From], edge.Version, ids[edge.To])
+	}
+
+	statuses := []string{}
+	for status := range graphColors {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		members := []string{}
+		for _, node := range g.Nodes {
+			if node.Status == status {
+				members = append(members, ids[node.ID])
+			}
+		}
+		if len(members) == 0 {
+			continue
+		}
+
+		class := status
+		if class == "" {
+			class = "unknown"
+		}
+		colors := graphColors[status]
+		fmt.Fprintf(builder, "  classDef %s fill:%s,stroke:%s\n", class, colors[0], colors[1])
+		fmt.Fprintf(builder, "  class %s %s\n", strings.Join(members, ","), class)
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
@@ -0,0 +1,98 @@
+package report
+
+import (
+	"bytes"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"gomod.alauda.cn/gomod-version-lint/pkg"
+	"strings"
+	"testing"
+)
+
+func TestGraphReporter(t *testing.T) {
+	mods := append(testMods[:0:0], testMods...)
+	mods[0].Requires = []module.Version{
+		{Path: "github.com/example/abc", Version: "v1.2.0"},
+		{Path: "github.com/example/lib", Version: "v0.3.0"},
+		{Path: "golang.org/x/mod", Version: "v0.10.0"},
+	}
+	opts := Options{
+		ModuleRegex: "github.com/example/.*",
+		ModulePaths: map[string]string{"go.mod": "github.com/example/app"},
+	}
+
+	cases := map[string][]string{
+		"dot": {
+			`"github.com/example/app" [label="github.com/example/app", fillcolor="#ddf4ff"`,
+			`"github.com/example/demo@v0.7.1-0.20230620020346-5e946b016f71" [label="github.com/example/demo\nv0.7.1-0.20230620020346-5e946b016f71", fillcolor="#ffebe9"`,
+			`"github.com/example/lib@v0.3.0" [label="github.com/example/lib\nv0.3.0", fillcolor="#ffffff", color="#8c959f", style="rounded,filled,dashed"];`,
+			`"github.com/example/app" -> "github.com/example/demo@v0.7.1-0.20230620020346-5e946b016f71" [label="v0.7.1-0.20230620020346-5e946b016f71"];`,
+			`"github.com/example/demo@v0.7.1-0.20230620020346-5e946b016f71" -> "github.com/example/abc@v1.2.0" [label="v1.2.0"];`,
+		},
+		"mermaid": {
+			"graph LR\n",
+			`n1["github.com/example/demo<br/>v0.7.1-0.20230620020346-5e946b016f71"]`,
+			`n1 -->|"v1.2.0"| n3`,
+			"classDef violation fill:#ffebe9,stroke:#cf222e\n  class n1 violation\n",
+			"class n3 compliant\n",
+		},
+	}
+
+	for format, expected := range cases {
+		reporter, err := New(format, opts)
+		if err != nil {
+			t.Errorf("should create %s reporter, but error: %s", format, err.Error())
+			continue
+		}
+
+		buf := &bytes.Buffer{}
+		if err := reporter.Report(buf, mods); err != nil {
+			t.Errorf("should report %s correctly, but error: %s", format, err.Error())
+			continue
+		}
+		for _, item := range expected {
+			if !strings.Contains(buf.String(), item) {
+				t.Errorf("%s should contain %q, but:\n%s", format, item, buf.String())
+			}
+		}
+		if strings.Contains(buf.String(), "golang.org/x/mod") {
+			t.Errorf("%s should not contain requires that are not internal", format)
+		}
+	}
+}
+
+func TestGraphReporterTransitive(t *testing.T) {
+	mods := append(testMods[:0:0], testMods[:1]...)
+	mods[0].Requires = []module.Version{{Path: "github.com/example/lib", Version: "v0.3.0"}}
+	mods = append(mods, pkg.ModRequireAnalysis{
+		Require:    modfile.Require{Mod: module.Version{Path: "github.com/example/lib", Version: "v0.3.0"}},
+		Transitive: true,
+		Branches:   []string{"feature-y"},
+		Findings:   []pkg.Finding{{Type: pkg.FindingBranchNotAllowed, Severity: pkg.SeverityError, Message: "branch is feature-y"}},
+		Requires:   []module.Version{{Path: "github.com/example/base", Version: "v0.0.0-20230620020346-5e946b016f71"}},
+	})
+	opts := Options{
+		ModuleRegex: "github.com/example/.*",
+		ModulePaths: map[string]string{"go.mod": "github.com/example/app"},
+	}
+
+	reporter, _ := New("dot", opts)
+	buf := &bytes.Buffer{}
+	if err := reporter.Report(buf, mods); err != nil {
+		t.Fatalf("should report dot correctly, but error: %s", err.Error())
+	}
+
+	expected := []string{
+		`"github.com/example/lib@v0.3.0" [label="github.com/example/lib\nv0.3.0", fillcolor="#ffebe9"`,
+		`"github.com/example/demo@v0.7.1-0.20230620020346-5e946b016f71" -> "github.com/example/lib@v0.3.0"`,
+		`"github.com/example/lib@v0.3.0" -> "github.com/example/base@v0.0.0-20230620020346-5e946b016f71"`,
+	}
+	for _, item := range expected {
+		if !strings.Contains(buf.String(), item) {
+			t.Errorf("dot should contain %q, but:\n%s", item, buf.String())
+		}
+	}
+	if strings.Contains(buf.String(), `"github.com/example/app" -> "github.com/example/lib@v0.3.0"`) {
+		t.Errorf("transitive modules should not be required by go.mod files, but:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,220 @@
+package report
+
+import (
+	_ "embed"
+	"golang.org/x/mod/module"
+	"gomod.alauda.cn/gomod-version-lint/pkg"
+	"html/template"
+	"io"
+	"sort"
+	"time"
+)
+
+//go:embed templates/report.html
+var htmlTemplate string
+
+// htmlReporter reports modules as a single static html file, styles and scripts are embedded so it works offline
+type htmlReporter struct {
+	opts Options
+	now  func() time.Time
+}
+
+type htmlReport struct {
+	GeneratedAt time.Time
+	Modules     []htmlModule
+	Lags        []htmlLag
+	Counts      map[string]int
+	// Graph dependency graph of the graph command drawn as svg
+	Graph htmlGraph
+}
+
+type htmlModule struct {
+	Module
+	Status     string
+	StatusText string
+	CommitURL  string
+	Branches   []htmlBranch
+	// LagDays days since the pinned commit of pseudo-version, it is -1 if the version is not a pseudo-version
+	LagDays int
+}
+
+type htmlBranch struct {
+	Name string
+	URL  string
+}
+
+type htmlLag struct {
+	Name    string
+	Days    int
+	Percent float64
+	Status  string
+}
+
+type htmlGraph struct {
+	Width  int
+	Height int
+	Nodes  []htmlGraphNode
+	Edges  []htmlGraphEdge
+}
+
+type htmlGraphNode struct {
+	graphNode
+	X, Y, Width int
+	Fill        string
+	Stroke      string
+}
+
+type htmlGraphEdge struct {
+	X1, Y1, X2, Y2 int
+}
+
+const (
+	graphNodeHeight = 40
+	graphRowGap     = 16
+	graphColumnGap  = 80
+	// graphCharWidth approximate width of a character of node label in pixel
+	graphCharWidth = 7
+)
+
+func (r *htmlReporter) Report(writer io.Writer, mods []pkg.ModRequireAnalysis) error {
+	now := time.Now
+	if r.now != nil {
+		now = r.now
+	}
+
+	tmpl, err := template.New("report.html").Parse(htmlTemplate)
+	if err != nil {
+		return err
+	}
+
+	g, err := (&graphReporter{opts: r.opts}).build(mods)
+	if err != nil {
+		return err
+	}
+
+	result := NewResult(mods, r.opts)
+	statuses := map[string][2]string{}
+	for _, item := range mods {
+		status, text := moduleStatus(item, r.opts)
+		statuses[modFile(item, r.opts)+"\x00"+item.Mod.String()] = [2]string{status, text}
+	}
+
+	report := htmlReport{GeneratedAt: now(), Counts: map[string]int{}, Graph: layoutGraph(g)}
+	maxDays := 0
+	for _, mod := range result.Modules {
+		status := statuses[mod.File+"\x00"+mod.String()]
+		item := htmlModule{
+			Module:     mod,
+			Status:     status[0],
+			StatusText: status[1],
+			CommitURL:  mod.CommitURL(),
+			LagDays:    -1,
+		}
+		for _, branch := range mod.Branches {
+			item.Branches = append(item.Branches, htmlBranch{Name: branch, URL: mod.BranchURL(branch)})
+		}
+		if commitTime, err := module.PseudoVersionTime(mod.Version); err == nil {
+			item.LagDays = int(report.GeneratedAt.Sub(commitTime).Hours() / 24)
+			report.Lags = append(report.Lags, htmlLag{Name: mod.String(), Days: item.LagDays, Status: item.Status})
+			if item.LagDays > maxDays {
+				maxDays = item.LagDays
+			}
+		}
+
+		report.Counts[item.Status]++
+		report.Modules = append(report.Modules, item)
+	}
+
+	sort.SliceStable(report.Lags, func(i, j int) bool { return report.Lags[i].Days > report.Lags[j].Days })
+	for i := range report.Lags {
+		report.Lags[i].Percent = 100
+		if maxDays > 0 {
+			report.Lags[i].Percent = float64(report.Lags[i].Days) * 100 / float64(maxDays)
+		}
+	}
+
+	return tmpl.Execute(writer, report)
+}
+
+// layoutGraph places nodes of graph in columns by their depth from go.mod files, edges go from left to right
+func layoutGraph(g graph) htmlGraph {
+	depths := map[string]int{}
+	for _, node := range g.Nodes {
+		if node.Status == "root" {
+			depths[node.ID] = 0
+		}
+	}
+	// edges are acyclic in practice, the loop is bounded by count of nodes in case of cycles
+	for i := 0; i < len(g.Nodes); i++ {
+		changed := false
+		for _, edge := range g.Edges {
+			from, ok := depths[edge.From]
+			if !ok {
+				continue
+			}
+			if to, ok := depths[edge.To]; !ok || to < from+1 {
+				depths[edge.To] = from + 1
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	columns := [][]int{}
+	for i, node := range g.Nodes {
+		depth := depths[node.ID]
+		for len(columns) <= depth {
+			columns = append(columns, []int{})
+		}
+		columns[depth] = append(columns[depth], i)
+	}
+
+	res := htmlGraph{Nodes: make([]htmlGraphNode, len(g.Nodes))}
+	positions := map[string]int{}
+	x := 0
+	for _, column := range columns {
+		width := 0
+		for row, i := range column {
+			node := g.Nodes[i]
+			nodeWidth := len(node.Path)
+			if len(node.Version) > nodeWidth {
+				nodeWidth = len(node.Version)
+			}
+			nodeWidth = nodeWidth*graphCharWidth + 16
+			if nodeWidth > width {
+				width = nodeWidth
+			}
+
+			colors := graphColors[node.Status]
+			res.Nodes[i] = htmlGraphNode{
+				graphNode: node,
+				X:         x,
+				Y:         row * (graphNodeHeight + graphRowGap),
+				Width:     nodeWidth,
+				Fill:      colors[0],
+				Stroke:    colors[1],
+			}
+			positions[node.ID] = i
+			if bottom := res.Nodes[i].Y + graphNodeHeight; bottom > res.Height {
+				res.Height = bottom
+			}
+		}
+		x += width + graphColumnGap
+	}
+	if x > 0 {
+		res.Width = x - graphColumnGap
+	}
+
+	for _, edge := range g.Edges {
+		from, to := res.Nodes[positions[edge.From]], res.Nodes[positions[edge.To]]
+		res.Edges = append(res.Edges, htmlGraphEdge{
+			X1: from.X + from.Width,
+			Y1: from.Y + graphNodeHeight/2,
+			X2: to.X,
+			Y2: to.Y + graphNodeHeight/2,
+		})
+	}
+	return res
+}
@@ -0,0 +1,50 @@
+package report
+
+import (
+	"bytes"
+	"gomod.alauda.cn/gomod-version-lint/pkg"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHtmlReporter(t *testing.T) {
+	mods := append(testMods[:0:0], testMods...)
+	mods[1].Signer = "release@example.com"
+	mods = append(mods, pkg.ModRequireAnalysis{
+		Require:  testMods[1].Require,
+		ModFile:  "tools/go.mod",
+		Error:    &pkg.AnalysisError{Class: pkg.ErrTimeout, Message: "context <deadline> exceeded"},
+		Findings: []pkg.Finding{{Type: pkg.FindingAnalysisError, Severity: pkg.SeverityError, Message: "context deadline exceeded"}},
+	})
+
+	reporter := &htmlReporter{now: func() time.Time { return time.Date(2023, 7, 20, 2, 3, 46, 0, time.UTC) }}
+	buf := &bytes.Buffer{}
+	if err := reporter.Report(buf, mods); err != nil {
+		t.Errorf("should report correctly, but error: %s", err.Error())
+		return
+	}
+
+	expected := []string{
+		`<tr data-status="violation">`,
+		`<a class="badge" href="https://github.com/example/demo/tree/feature-x">feature-x</a>`,
+		`<a href="https://github.com/example/demo/commit/5e946b016f71">`,
+		`<td data-value="30">30</td>`,
+		`<td data-value="release@example.com">release@example.com</td>`,
+		`<div class="bar bar-violation" style="width: 100.0%; max-width: 50%;"></div>`,
+		`<pre>context &lt;deadline&gt; exceeded</pre>`,
+		`❌ violation: 1`,
+		`<h2>Dependency graph</h2>`,
+		`<rect x="0" y="0" width="58" height="40" rx="6" fill="#ddf4ff" stroke="#0969da"></rect>`,
+		`<title>github.com/example/demo@v0.7.1-0.20230620020346-5e946b016f71</title>`,
+		`fill="#ffebe9" stroke="#cf222e"`,
+	}
+	for _, item := range expected {
+		if !strings.Contains(buf.String(), item) {
+			t.Errorf("html should contain %q", item)
+		}
+	}
+	if strings.Contains(buf.String(), "http://") || strings.Contains(buf.String(), "<link") || strings.Contains(buf.String(), "<script src") {
+		t.Errorf("html should not load external assets")
+	}
+}
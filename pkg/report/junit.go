@@ -0,0 +1,133 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"gomod.alauda.cn/gomod-version-lint/pkg"
+	"io"
+	"strings"
+	"time"
+)
+
+// junitReporter reports each module as a testcase of JUnit XML, modules with violations are failed,
+// modules that analysis failed are errors, the duration of analysis is recorded as the time of testcase
+type junitReporter struct {
+	opts Options
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (r *junitReporter) Report(writer io.Writer, mods []pkg.ModRequireAnalysis) error {
+	suites := junitTestSuites{}
+	suiteIndex := map[string]int{}
+	durations := map[string]time.Duration{}
+	var total time.Duration
+
+	for _, item := range mods {
+		file := modFile(item, r.opts)
+		if _, ok := suiteIndex[file]; !ok {
+			suiteIndex[file] = len(suites.Suites)
+			suites.Suites = append(suites.Suites, junitTestSuite{Name: file})
+		}
+		suite := &suites.Suites[suiteIndex[file]]
+
+		testCase := junitTestCase{
+			Name:      item.Mod.Path + "@" + item.Mod.Version,
+			ClassName: file,
+			Time:      junitTime(item.Duration),
+		}
+
+		details := []string{fmt.Sprintf("%s:%d", file, line(item)), "branches: " + strings.Join(item.Branches, ", ")}
+		violations := []string{}
+		errors := []string{}
+		for _, finding := range item.Findings {
+			text := fmt.Sprintf("[%s] %s: %s", finding.Severity, finding.Type, finding.Message)
+			if finding.SuggestedVersion != "" {
+				text = text + ", suggested version: " + finding.SuggestedVersion
+			}
+			if finding.SuppressedBy != "" {
+				text = text + " (suppressed by " + finding.SuppressedBy + ")"
+			}
+			details = append(details, text)
+
+			if !isViolation(finding, r.opts) {
+				continue
+			}
+			if finding.Type == pkg.FindingAnalysisError {
+				errors = append(errors, finding.Message)
+			} else {
+				violations = append(violations, finding.Message)
+			}
+		}
+
+		switch {
+		case len(errors) > 0:
+			testCase.Error = &junitMessage{Message: strings.Join(errors, "; "), Type: string(pkg.FindingAnalysisError), Text: strings.Join(details, "\n")}
+			suite.Errors++
+		case len(violations) > 0:
+			testCase.Failure = &junitMessage{Message: strings.Join(violations, "; "), Type: "violation", Text: strings.Join(details, "\n")}
+			suite.Failures++
+		default:
+			testCase.SystemOut = strings.Join(details, "\n")
+		}
+
+		suite.Tests++
+		suite.TestCases = append(suite.TestCases, testCase)
+		durations[file] += item.Duration
+		total += item.Duration
+	}
+
+	for i := range suites.Suites {
+		suites.Suites[i].Time = junitTime(durations[suites.Suites[i].Name])
+		suites.Tests += suites.Suites[i].Tests
+		suites.Failures += suites.Suites[i].Failures
+		suites.Errors += suites.Suites[i].Errors
+	}
+	suites.Time = junitTime(total)
+
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(writer, "\n")
+	return err
+}
+
+func junitTime(duration time.Duration) string {
+	return fmt.Sprintf("%.3f", duration.Seconds())
+}
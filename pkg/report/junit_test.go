@@ -0,0 +1,44 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func TestJunitReporter(t *testing.T) {
+	mods := append(testMods[:0:0], testMods...)
+	mods[0].Duration = 1500 * time.Millisecond
+
+	reporter, err := New("junit", Options{FailOn: "error"})
+	if err != nil {
+		t.Errorf("should create junit reporter, but error: %s", err.Error())
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	if err := reporter.Report(buf, mods); err != nil {
+		t.Errorf("should report correctly, but error: %s", err.Error())
+		return
+	}
+
+	suites := junitTestSuites{}
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Errorf("should be valid xml, but error: %s", err.Error())
+		return
+	}
+
+	if suites.Tests != 2 || suites.Failures != 1 || suites.Errors != 0 || len(suites.Suites) != 2 {
+		t.Errorf("should have 2 tests in 2 suites and 1 failure, but: %s", buf.String())
+		return
+	}
+
+	failed := suites.Suites[0].TestCases[0]
+	if failed.Failure == nil || failed.Time != "1.500" || !bytes.Contains([]byte(failed.Failure.Text), []byte("branches: feature-x")) {
+		t.Errorf("testcase should fail with branches in 1.500s, but: %#v", failed)
+	}
+	if passed := suites.Suites[1].TestCases[0]; passed.Failure != nil || passed.Error != nil {
+		t.Errorf("compliant module should pass, but: %#v", passed)
+	}
+}
@@ -0,0 +1,91 @@
+package report
+
+import (
+	"fmt"
+	"gomod.alauda.cn/gomod-version-lint/pkg"
+	"io"
+	"strings"
+)
+
+// markdownReporter reports modules as markdown tables grouped by go.mod file, details of analysis errors are collapsible,
+// it is suitable for job summary of CI and body of pull request comment
+type markdownReporter struct {
+	opts Options
+}
+
+func (r *markdownReporter) Report(writer io.Writer, mods []pkg.ModRequireAnalysis) error {
+	builder := &strings.Builder{}
+	builder.WriteString("## gomod-version-lint\n\n")
+	if len(mods) == 0 {
+		builder.WriteString("no modules matched\n")
+		_, err := io.WriteString(writer, builder.String())
+		return err
+	}
+
+	files := []string{}
+	groups := map[string][]pkg.ModRequireAnalysis{}
+	for _, item := range mods {
+		file := modFile(item, r.opts)
+		if _, ok := groups[file]; !ok {
+			files = append(files, file)
+		}
+		groups[file] = append(groups[file], item)
+	}
+
+	for _, file := range files {
+		fmt.Fprintf(builder, "### %s\n\n", file)
+		builder.WriteString("| Module | Version | Branches | Signer | Status | Suggested fix |\n")
+		builder.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+		for _, item := range groups[file] {
+			fmt.Fprintf(builder, "| %s | `%s` | %s | %s | %s | %s |\n",
+				markdownCell(item.Mod.Path),
+				item.Mod.Version,
+				markdownCell(strings.Join(item.Branches, ", ")),
+				markdownCell(item.Signer),
+				markdownCell(r.status(item)),
+				markdownCell(suggestedFix(item)),
+			)
+		}
+		builder.WriteString("\n")
+
+		for _, item := range groups[file] {
+			if item.Error == nil {
+				continue
+			}
+			fmt.Fprintf(builder, "<details>\n<summary>🐛 %s@%s: %s</summary>\n\n```\n%s\n```\n\n</details>\n\n",
+				item.Mod.Path, item.Mod.Version, item.Error.Class, item.Error.Message)
+		}
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// status returns status of module with emoji
+func (r *markdownReporter) status(item pkg.ModRequireAnalysis) string {
+	status, text := moduleStatus(item, r.opts)
+	return statusIcons[status] + " " + text
+}
+
+var statusIcons = map[string]string{
+	statusError:      "🐛",
+	statusViolation:  "❌",
+	statusWarning:    "⚠️",
+	statusSuppressed: "🔕",
+	statusCompliant:  "✅",
+}
+
+// suggestedFix returns the first suggested version of findings that are not suppressed
+func suggestedFix(item pkg.ModRequireAnalysis) string {
+	for _, finding := range item.Findings {
+		if finding.SuppressedBy == "" && finding.SuggestedVersion != "" {
+			return "`" + finding.SuggestedVersion + "`"
+		}
+	}
+	return ""
+}
+
+func markdownCell(str string) string {
+	str = strings.ReplaceAll(str, "|", "\\|")
+	return strings.ReplaceAll(str, "\n", " ")
+}
@@ -0,0 +1,52 @@
+package report
+
+import (
+	"bytes"
+	"gomod.alauda.cn/gomod-version-lint/pkg"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownReporter(t *testing.T) {
+	mods := append(testMods[:0:0], testMods...)
+	mods = append(mods, pkg.ModRequireAnalysis{
+		Require:  testMods[1].Require,
+		ModFile:  "tools/go.mod",
+		Error:    &pkg.AnalysisError{Class: pkg.ErrTimeout, Message: "context deadline exceeded"},
+		Findings: []pkg.Finding{{Type: pkg.FindingAnalysisError, Severity: pkg.SeverityError, Message: "context deadline exceeded"}},
+	}, pkg.ModRequireAnalysis{
+		Require:  testMods[1].Require,
+		ModFile:  "info/go.mod",
+		Branches: []string{"main"},
+		Signer:   "release@example.com",
+		Findings: []pkg.Finding{{Type: pkg.FindingNewerRelease, Severity: pkg.SeverityInfo, Message: "newer release v1.3.0", SuggestedVersion: "v1.3.0"}},
+	})
+
+	reporter, err := New("markdown", Options{})
+	if err != nil {
+		t.Errorf("should create markdown reporter, but error: %s", err.Error())
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	if err := reporter.Report(buf, mods); err != nil {
+		t.Errorf("should report correctly, but error: %s", err.Error())
+		return
+	}
+
+	expected := []string{
+		"### go.mod\n",
+		"| github.com/example/demo | `v0.7.1-0.20230620020346-5e946b016f71` | feature-x |  | ❌ branches feature-x are not allowed |  |\n",
+		"### tools/go.mod\n",
+		"| github.com/example/abc | `v1.2.0` | main |  | ✅ compliant |  |\n",
+		"| github.com/example/abc | `v1.2.0` |  |  | 🐛 timeout |  |\n",
+		"<details>\n<summary>🐛 github.com/example/abc@v1.2.0: timeout</summary>",
+		// info findings are hints, the module is still compliant
+		"| github.com/example/abc | `v1.2.0` | main | release@example.com | ✅ newer release v1.3.0 | `v1.3.0` |\n",
+	}
+	for _, item := range expected {
+		if !strings.Contains(buf.String(), item) {
+			t.Errorf("markdown should contain %q, but:\n%s", item, buf.String())
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package report
+
+import (
+	"golang.org/x/mod/module"
+	"gomod.alauda.cn/gomod-version-lint/pkg"
+	"sort"
+	"time"
+)
+
+// SchemaVersion version of the schema of Result, it is increased when fields are removed or changed incompatibly,
+// see schema/result.schema.json
+const SchemaVersion = "1"
+
+// Result stable model of analysis result, it is independent of the internals of analysis
+type Result struct {
+	SchemaVersion string   `json:"schemaVersion" yaml:"schemaVersion"`
+	Modules       []Module `json:"modules" yaml:"modules"`
+}
+
+// Module analysis result of a module required in a go.mod file
+type Module struct {
+	Path    string `json:"path" yaml:"path"`
+	Version string `json:"version" yaml:"version"`
+	// Commit full hash of the commit that the version resolved to, it is the revision of pseudo-version
+	// if the repository could not be analysed, and empty if neither is known
+	Commit string `json:"commit,omitempty" yaml:"commit,omitempty"`
+	// File path of go.mod file that requires the module
+	File     string    `json:"file" yaml:"file"`
+	Line     int       `json:"line" yaml:"line"`
+	Branches []string  `json:"branches" yaml:"branches"`
+	Findings []Finding `json:"findings" yaml:"findings"`
+	Error    *Error    `json:"error,omitempty" yaml:"error,omitempty"`
+	// Signer identity of the signer of the version, it is only verified when signature verification is enabled
+	Signer string `json:"signer,omitempty" yaml:"signer,omitempty"`
+	// PullRequests open or merged upstream pull requests whose head branch contains the version
+	PullRequests []PullRequest `json:"pullRequests,omitempty" yaml:"pullRequests,omitempty"`
+	// CIStatuses ci statuses of the version, they are only checked when ci is required
+	CIStatuses []CIStatus `json:"ciStatuses,omitempty" yaml:"ciStatuses,omitempty"`
+	// Requires requires in go.mod of the module at the pinned revision
+	Requires []Require `json:"requires,omitempty" yaml:"requires,omitempty"`
+	// Duration time spent on fetching and analysing the module
+	Duration time.Duration `json:"-" yaml:"-"`
+	// DurationMs milliseconds of Duration
+	DurationMs int64 `json:"durationMs" yaml:"durationMs"`
+}
+
+// Finding problem found on the module
+type Finding struct {
+	Type             string `json:"type" yaml:"type"`
+	Severity         string `json:"severity" yaml:"severity"`
+	Message          string `json:"message" yaml:"message"`
+	SuggestedVersion string `json:"suggestedVersion,omitempty" yaml:"suggestedVersion,omitempty"`
+	SuppressedBy     string `json:"suppressedBy,omitempty" yaml:"suppressedBy,omitempty"`
+}
+
+// PullRequest upstream pull request or merge request that contains the version
+type PullRequest struct {
+	ID         int    `json:"id" yaml:"id"`
+	Title      string `json:"title" yaml:"title"`
+	URL        string `json:"url" yaml:"url"`
+	HeadBranch string `json:"headBranch" yaml:"headBranch"`
+	// State open or merged
+	State     string `json:"state" yaml:"state"`
+	Approvals int    `json:"approvals" yaml:"approvals"`
+	// ApprovalsUnknown approvals could not be listed, Approvals is zero then
+	ApprovalsUnknown bool `json:"approvalsUnknown,omitempty" yaml:"approvalsUnknown,omitempty"`
+}
+
+// CIStatus status of a ci context on the commit of version
+type CIStatus struct {
+	Context string `json:"context" yaml:"context"`
+	// State success, pending or failure
+	State string `json:"state" yaml:"state"`
+	URL   string `json:"url,omitempty" yaml:"url,omitempty"`
+}
+
+// Require require in go.mod of the module
+type Require struct {
+	Path    string `json:"path" yaml:"path"`
+	Version string `json:"version" yaml:"version"`
+}
+
+// Error error of analysis
+type Error struct {
+	Class   string `json:"class" yaml:"class"`
+	Message string `json:"message" yaml:"message"`
+}
+
+// NewResult converts analysis of modules to result, modules are sorted by go.mod file, line, path and version
+func NewResult(mods []pkg.ModRequireAnalysis, opts Options) Result {
+	result := Result{SchemaVersion: SchemaVersion, Modules: []Module{}}
+	for _, item := range mods {
+		mod := Module{
+			Path:       item.Mod.Path,
+			Version:    item.Mod.Version,
+			File:       modFile(item, opts),
+			Line:       line(item),
+			Branches:   append([]string{}, item.Branches...),
+			Findings:   []Finding{},
+			Commit:     item.Commit,
+			Duration:   item.Duration,
+			DurationMs: item.Duration.Milliseconds(),
+			Signer:     item.Signer,
+		}
+		if mod.Commit == "" && module.IsPseudoVersion(item.Mod.Version) {
+			mod.Commit, _ = module.PseudoVersionRev(item.Mod.Version)
+		}
+		for _, pr := range item.PullRequests {
+			mod.PullRequests = append(mod.PullRequests, PullRequest{
+				ID:               pr.ID,
+				Title:            pr.Title,
+				URL:              pr.URL,
+				HeadBranch:       pr.HeadBranch,
+				State:            pr.State,
+				Approvals:        pr.Approvals,
+				ApprovalsUnknown: pr.ApprovalsUnknown,
+			})
+		}
+		for _, status := range item.CommitStatuses {
+			mod.CIStatuses = append(mod.CIStatuses, CIStatus{Context: status.Context, State: status.State, URL: status.URL})
+		}
+		for _, require := range item.Requires {
+			mod.Requires = append(mod.Requires, Require{Path: require.Path, Version: require.Version})
+		}
+		for _, finding := range item.Findings {
+			mod.Findings = append(mod.Findings, Finding{
+				Type:             string(finding.Type),
+				Severity:         string(finding.Severity),
+				Message:          finding.Message,
+				SuggestedVersion: finding.SuggestedVersion,
+				SuppressedBy:     finding.SuppressedBy,
+			})
+		}
+		if item.Error != nil {
+			mod.Error = &Error{Class: string(item.Error.Class), Message: item.Error.Message}
+		}
+		result.Modules = append(result.Modules, mod)
+	}
+
+	sort.SliceStable(result.Modules, func(i, j int) bool {
+		a, b := result.Modules[i], result.Modules[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Version < b.Version
+	})
+	return result
+}
+
+// CommitURL returns web url of the commit of module, it is empty if the git server is unknown
+func (mod Module) CommitURL() string {
+	return pkg.CommitURL(mod.Path, mod.Commit)
+}
+
+// BranchURL returns web url of the branch of module, it is empty if the git server is unknown
+func (mod Module) BranchURL(branch string) string {
+	return pkg.BranchURL(mod.Path, branch)
+}
+
+func (mod Module) String() string {
+	return mod.Path + "@" + mod.Version
+}
@@ -0,0 +1,168 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"gomod.alauda.cn/gomod-version-lint/pkg"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Reporter writes analysis result of modules in a specific format
+type Reporter interface {
+	Report(writer io.Writer, mods []pkg.ModRequireAnalysis) error
+}
+
+// Options common options of reporters
+type Options struct {
+	// ModFile path of go.mod file, it is used when go.mod file of module is unknown
+	ModFile string
+	// FailOn findings with severity equal or higher than it are violations, default is error, none means no violations
+	FailOn string
+	// Template path of text/template file for template format
+	Template string
+	// ModuleRegex regex of internal modules, requires of dependencies that matched it are rendered in graph formats
+	ModuleRegex string
+	// ModulePaths module paths of go.mod files, they are the root nodes of graph formats
+	ModulePaths map[string]string
+}
+
+// New returns reporter of the format
+func New(format string, opts Options) (Reporter, error) {
+	switch format {
+	case "json", "yaml":
+		return &resultReporter{format: format, opts: opts}, nil
+	case "sarif":
+		return &sarifReporter{opts: opts}, nil
+	case "junit":
+		return &junitReporter{opts: opts}, nil
+	case "codequality":
+		return &codeQualityReporter{opts: opts}, nil
+	case "checkstyle":
+		return &checkstyleReporter{opts: opts}, nil
+	case "markdown":
+		return &markdownReporter{opts: opts}, nil
+	case "github":
+		return &githubReporter{opts: opts}, nil
+	case "template":
+		return newTemplateReporter(opts)
+	case "html":
+		return &htmlReporter{opts: opts}, nil
+	case "dot", "mermaid":
+		return &graphReporter{format: format, opts: opts}, nil
+	}
+	return nil, fmt.Errorf("unknown output format: %s", format)
+}
+
+// modFile returns slash separated path of go.mod file that requires the module
+func modFile(item pkg.ModRequireAnalysis, opts Options) string {
+	file := item.ModFile
+	if file == "" {
+		file = opts.ModFile
+	}
+	if file == "" {
+		file = "go.mod"
+	}
+	return filepath.ToSlash(filepath.Clean(file))
+}
+
+// line returns line of the require in go.mod file
+func line(item pkg.ModRequireAnalysis) int {
+	if item.Syntax == nil {
+		return 1
+	}
+	return item.Syntax.Start.Line
+}
+
+// fingerprint returns stable fingerprint of the finding, it is based on the go.mod file, module path, finding type and
+// key, so it does not change when the line of the require or the message changes, and findings of the same type are
+// distinguished by their keys, eg. different advisories of a module
+func fingerprint(item pkg.ModRequireAnalysis, finding pkg.Finding, opts Options) string {
+	value := modFile(item, opts) + ":" + item.Mod.Path + ":" + string(finding.Type)
+	if finding.Key != "" {
+		value = value + ":" + finding.Key
+	}
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// isViolation returns true if the finding is not suppressed and its severity is equal or higher than fail on
+func isViolation(finding pkg.Finding, opts Options) bool {
+	failOn := opts.FailOn
+	if failOn == "" {
+		failOn = string(pkg.SeverityError)
+	}
+	if failOn == "none" || finding.SuppressedBy != "" {
+		return false
+	}
+	return finding.Severity.AtLeast(pkg.Severity(failOn))
+}
+
+// findingMessage returns message of the finding with module version and suggested version
+func findingMessage(item pkg.ModRequireAnalysis, finding pkg.Finding) string {
+	message := item.Mod.Path + "@" + item.Mod.Version + ": " + finding.Message
+	if finding.SuggestedVersion != "" {
+		message = message + ", suggested version: " + finding.SuggestedVersion
+	}
+	return message
+}
+
+// findingDescription returns message of the finding with branches that contain the version
+func findingDescription(item pkg.ModRequireAnalysis, finding pkg.Finding) string {
+	branches := "none"
+	if len(item.Branches) > 0 {
+		branches = strings.Join(item.Branches, ", ")
+	}
+	return findingMessage(item, finding) + " (branches: " + branches + ")"
+}
+
+const (
+	statusError      = "error"
+	statusViolation  = "violation"
+	statusWarning    = "warning"
+	statusSuppressed = "suppressed"
+	statusCompliant  = "compliant"
+)
+
+// moduleStatus returns status of module and the message of the most severe finding that is not suppressed
+func moduleStatus(item pkg.ModRequireAnalysis, opts Options) (status string, text string) {
+	if item.Error != nil && hasUnsuppressed(item, pkg.FindingAnalysisError) {
+		return statusError, string(item.Error.Class)
+	}
+
+	var worst *pkg.Finding
+	suppressed := false
+	for i, finding := range item.Findings {
+		if finding.SuppressedBy != "" {
+			suppressed = true
+			continue
+		}
+		if worst == nil || !worst.Severity.AtLeast(finding.Severity) {
+			worst = &item.Findings[i]
+		}
+	}
+
+	switch {
+	case worst != nil && isViolation(*worst, opts):
+		return statusViolation, worst.Message
+	case worst != nil && worst.Severity.AtLeast(pkg.SeverityWarning):
+		return statusWarning, worst.Message
+	case suppressed:
+		return statusSuppressed, "suppressed"
+	case worst != nil:
+		// info findings are hints, eg. newer release, module is still compliant
+		return statusCompliant, worst.Message
+	}
+	return statusCompliant, "compliant"
+}
+
+func hasUnsuppressed(item pkg.ModRequireAnalysis, findingType pkg.FindingType) bool {
+	for _, finding := range item.Findings {
+		if finding.Type == findingType && finding.SuppressedBy == "" {
+			return true
+		}
+	}
+	return false
+}
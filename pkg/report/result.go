@@ -0,0 +1,31 @@
+package report
+
+import (
+	"encoding/json"
+	"gomod.alauda.cn/gomod-version-lint/pkg"
+	"gopkg.in/yaml.v3"
+	"io"
+)
+
+// resultReporter reports Result in json or yaml, the schema is versioned by SchemaVersion
+type resultReporter struct {
+	format string
+	opts   Options
+}
+
+func (r *resultReporter) Report(writer io.Writer, mods []pkg.ModRequireAnalysis) error {
+	result := NewResult(mods, r.opts)
+
+	if r.format == "yaml" {
+		encoder := yaml.NewEncoder(writer)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
@@ -0,0 +1,102 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"golang.org/x/mod/module"
+	pkgscm "gomod.alauda.cn/gomod-version-lint/pkg/scm"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestResultReporter(t *testing.T) {
+	mods := append(testMods[:0:0], testMods...)
+	mods[0].Duration = 1500 * time.Millisecond
+	mods[0], mods[1] = mods[1], mods[0]
+
+	reporter, err := New("json", Options{})
+	if err != nil {
+		t.Errorf("should create json reporter, but error: %s", err.Error())
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	if err := reporter.Report(buf, mods); err != nil {
+		t.Errorf("should report correctly, but error: %s", err.Error())
+		return
+	}
+
+	result := Result{}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Errorf("should be valid json, but error: %s", err.Error())
+		return
+	}
+	if result.SchemaVersion != SchemaVersion || len(result.Modules) != 2 {
+		t.Errorf("should have schema version and 2 modules, but: %s", buf.String())
+		return
+	}
+	if result.Modules[0].File != "go.mod" || result.Modules[1].File != "tools/go.mod" {
+		t.Errorf("modules should be sorted by file, but: %s", buf.String())
+	}
+	if result.Modules[0].Commit != "5e946b016f71" || result.Modules[0].DurationMs != 1500 {
+		t.Errorf("commit and duration should be reported, but: %s", buf.String())
+	}
+}
+
+// TestResultSchema makes sure that all fields of result are documented in the published json schema
+func TestResultSchema(t *testing.T) {
+	bts, err := os.ReadFile("../../schema/result.schema.json")
+	if err != nil {
+		t.Errorf("should read schema file, but error: %s", err.Error())
+		return
+	}
+
+	schema := struct {
+		Properties map[string]interface{} `json:"properties"`
+		Defs       map[string]struct {
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"$defs"`
+	}{}
+	if err := json.Unmarshal(bts, &schema); err != nil {
+		t.Errorf("schema should be valid json, but error: %s", err.Error())
+		return
+	}
+
+	mods := append(testMods[:0:0], testMods...)
+	mods[0].Commit = "5e946b016f71d5b3f3a7bda7e84c13ed50a0a2f1"
+	mods[0].Signer = "dev@example.com"
+	mods[0].PullRequests = []pkgscm.PullRequest{{ID: 1, URL: "https://github.com/example/demo/pull/1", State: pkgscm.PullRequestOpen, ApprovalsUnknown: true}}
+	mods[0].CommitStatuses = []pkgscm.CommitStatus{{Context: "build", State: pkgscm.CommitStatusSuccess, URL: "https://ci.example.com/1"}}
+	mods[0].Requires = []module.Version{{Path: "github.com/example/lib", Version: "v0.3.0"}}
+	mod := NewResult(mods, Options{}).Modules[0]
+	if mod.Commit != mods[0].Commit {
+		t.Errorf("commit should be the resolved commit %s, but: %s", mods[0].Commit, mod.Commit)
+	}
+	mod.Error = &Error{Class: "timeout", Message: "timeout"}
+	objects := map[string]interface{}{
+		"":            Result{},
+		"module":      mod,
+		"finding":     mod.Findings[1],
+		"error":       mod.Error,
+		"pullRequest": mod.PullRequests[0],
+		"ciStatus":    mod.CIStatuses[0],
+		"require":     mod.Requires[0],
+	}
+
+	for def, object := range objects {
+		properties := schema.Properties
+		if def != "" {
+			properties = schema.Defs[def].Properties
+		}
+
+		bts, _ := json.Marshal(object)
+		fields := map[string]interface{}{}
+		json.Unmarshal(bts, &fields)
+		for field := range fields {
+			if _, ok := properties[field]; !ok {
+				t.Errorf("field %s of %q should be in the schema", field, def)
+			}
+		}
+	}
+}
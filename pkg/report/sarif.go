@@ -0,0 +1,151 @@
+package report
+
+import (
+	"encoding/json"
+	"gomod.alauda.cn/gomod-version-lint/pkg"
+	"io"
+	"sort"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// sarifReporter reports findings in SARIF 2.1.0, one rule per finding type,
+// see https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type sarifReporter struct {
+	opts Options
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string             `json:"ruleId"`
+	RuleIndex           int                `json:"ruleIndex"`
+	Level               string             `json:"level"`
+	Message             sarifMessage       `json:"message"`
+	Locations           []sarifLocation    `json:"locations"`
+	PartialFingerprints map[string]string  `json:"partialFingerprints"`
+	Suppressions        []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+func (r *sarifReporter) Report(writer io.Writer, mods []pkg.ModRequireAnalysis) error {
+	types := map[pkg.FindingType]bool{}
+	for _, item := range mods {
+		for _, finding := range item.Findings {
+			types[finding.Type] = true
+		}
+	}
+
+	rules := []sarifRule{}
+	for findingType := range types {
+		rules = append(rules, sarifRule{ID: string(findingType), ShortDescription: sarifMessage{Text: findingType.Description()}})
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	ruleIndex := map[string]int{}
+	for i, rule := range rules {
+		ruleIndex[rule.ID] = i
+	}
+
+	results := []sarifResult{}
+	for _, item := range mods {
+		for _, finding := range item.Findings {
+			result := sarifResult{
+				RuleID:    string(finding.Type),
+				RuleIndex: ruleIndex[string(finding.Type)],
+				Level:     sarifLevel(finding.Severity),
+				Message:   sarifMessage{Text: findingMessage(item, finding)},
+				Locations: []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: modFile(item, r.opts)},
+						Region:           sarifRegion{StartLine: line(item)},
+					},
+				}},
+				PartialFingerprints: map[string]string{
+					"modulePath/v1": fingerprint(item, finding, r.opts),
+				},
+			}
+			if finding.SuppressedBy != "" {
+				kind := "inSource"
+				if finding.SuppressedBy == pkg.BaselineSuppression {
+					kind = "external"
+				}
+				result.Suppressions = []sarifSuppression{{Kind: kind, Justification: "suppressed by " + finding.SuppressedBy}}
+			}
+			results = append(results, result)
+		}
+	}
+
+	log := sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "gomod-version-lint", Rules: rules}},
+			Results: results,
+		}},
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+func sarifLevel(severity pkg.Severity) string {
+	switch severity {
+	case pkg.SeverityError:
+		return "error"
+	case pkg.SeverityWarning:
+		return "warning"
+	}
+	return "note"
+}
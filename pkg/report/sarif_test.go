@@ -0,0 +1,78 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"gomod.alauda.cn/gomod-version-lint/pkg"
+	"testing"
+)
+
+var testMods = []pkg.ModRequireAnalysis{
+	{
+		Require: modfile.Require{
+			Mod:    module.Version{Path: "github.com/example/demo", Version: "v0.7.1-0.20230620020346-5e946b016f71"},
+			Syntax: &modfile.Line{Start: modfile.Position{Line: 12}},
+		},
+		ModFile:  "go.mod",
+		Branches: []string{"feature-x"},
+		Findings: []pkg.Finding{
+			{Type: pkg.FindingBranchNotAllowed, Severity: pkg.SeverityError, Message: "branches feature-x are not allowed"},
+			{Type: pkg.FindingNewerRelease, Severity: pkg.SeverityInfo, Message: "newer release v0.8.0", SuggestedVersion: "v0.8.0", SuppressedBy: pkg.BaselineSuppression},
+		},
+	},
+	{
+		Require: modfile.Require{
+			Mod:    module.Version{Path: "github.com/example/abc", Version: "v1.2.0"},
+			Syntax: &modfile.Line{Start: modfile.Position{Line: 13}},
+		},
+		ModFile:  "tools/go.mod",
+		Branches: []string{"main"},
+	},
+}
+
+func TestSarifReporter(t *testing.T) {
+	reporter, err := New("sarif", Options{})
+	if err != nil {
+		t.Errorf("should create sarif reporter, but error: %s", err.Error())
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	if err := reporter.Report(buf, testMods); err != nil {
+		t.Errorf("should report correctly, but error: %s", err.Error())
+		return
+	}
+
+	log := sarifLog{}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Errorf("should be valid json, but error: %s", err.Error())
+		return
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Errorf("should be sarif 2.1.0 with one run, but: %s", buf.String())
+		return
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 2 || run.Tool.Driver.Rules[0].ID != string(pkg.FindingBranchNotAllowed) {
+		t.Errorf("should have one rule per finding type, but: %#v", run.Tool.Driver.Rules)
+	}
+	if len(run.Results) != 2 {
+		t.Errorf("should have 2 results, but: %d", len(run.Results))
+		return
+	}
+
+	result := run.Results[0]
+	location := result.Locations[0].PhysicalLocation
+	if result.Level != "error" || location.ArtifactLocation.URI != "go.mod" || location.Region.StartLine != 12 {
+		t.Errorf("result should be error at go.mod:12, but: %#v", result)
+	}
+	if result.PartialFingerprints["modulePath/v1"] == "" || result.PartialFingerprints["modulePath/v1"] == run.Results[1].PartialFingerprints["modulePath/v1"] {
+		t.Errorf("fingerprints should be unique for each finding type, but: %#v", run.Results)
+	}
+	if len(run.Results[1].Suppressions) != 1 || run.Results[1].Suppressions[0].Kind != "external" {
+		t.Errorf("finding suppressed by baseline should be suppressed externally, but: %#v", run.Results[1].Suppressions)
+	}
+}
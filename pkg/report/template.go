@@ -0,0 +1,79 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"gomod.alauda.cn/gomod-version-lint/pkg"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// templateReporter executes text/template over Result, the template could use helper functions:
+//
+//	join ", " .Branches      joins strings with separator
+//	pad 40 .Path             pads string with spaces to width
+//	csv .Path .Version       quotes values as a csv record
+//	commitURL .              web url of the commit of module
+//	branchURL . "main"       web url of the branch of module
+//	violation .              returns true if the finding is a violation
+type templateReporter struct {
+	opts     Options
+	template *template.Template
+}
+
+func newTemplateReporter(opts Options) (*templateReporter, error) {
+	if opts.Template == "" {
+		return nil, fmt.Errorf("template file should be provided for template format")
+	}
+
+	bts, err := os.ReadFile(opts.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &templateReporter{opts: opts}
+	r.template, err = template.New(filepath.Base(opts.Template)).Funcs(r.funcs()).Parse(string(bts))
+	if err != nil {
+		return nil, fmt.Errorf("parse template %s error: %s", opts.Template, err.Error())
+	}
+	return r, nil
+}
+
+func (r *templateReporter) Report(writer io.Writer, mods []pkg.ModRequireAnalysis) error {
+	return r.template.Execute(writer, NewResult(mods, r.opts))
+}
+
+func (r *templateReporter) funcs() template.FuncMap {
+	return template.FuncMap{
+		"join": func(sep string, items []string) string {
+			return strings.Join(items, sep)
+		},
+		"pad": func(width int, str string) string {
+			if len(str) >= width {
+				return str
+			}
+			return str + strings.Repeat(" ", width-len(str))
+		},
+		"csv": func(values ...string) (string, error) {
+			builder := &strings.Builder{}
+			writer := csv.NewWriter(builder)
+			if err := writer.Write(values); err != nil {
+				return "", err
+			}
+			writer.Flush()
+			return strings.TrimSuffix(builder.String(), "\n"), writer.Error()
+		},
+		"commitURL": func(mod Module) string {
+			return mod.CommitURL()
+		},
+		"branchURL": func(mod Module, branch string) string {
+			return mod.BranchURL(branch)
+		},
+		"violation": func(finding Finding) bool {
+			return isViolation(pkg.Finding{Severity: pkg.Severity(finding.Severity), SuppressedBy: finding.SuppressedBy}, r.opts)
+		},
+	}
+}
@@ -0,0 +1,47 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var csvTemplate = `module,version,branches,commit
+{{- range .Modules }}
+{{ csv .Path .Version (join " " .Branches) (commitURL .) }}
+{{- end }}
+{{ range .Modules }}{{ range .Findings }}{{ if violation . }}{{ pad 20 .Type }}|{{ end }}{{ end }}{{ end }}
+`
+
+func TestTemplateReporter(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "modules.csv.tmpl")
+	if err := os.WriteFile(file, []byte(csvTemplate), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := New("template", Options{}); err == nil {
+		t.Errorf("should return error when template file is not provided")
+	}
+
+	reporter, err := New("template", Options{Template: file})
+	if err != nil {
+		t.Errorf("should create template reporter, but error: %s", err.Error())
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	if err := reporter.Report(buf, testMods); err != nil {
+		t.Errorf("should report correctly, but error: %s", err.Error())
+		return
+	}
+
+	expected := `module,version,branches,commit
+github.com/example/demo,v0.7.1-0.20230620020346-5e946b016f71,feature-x,https://github.com/example/demo/commit/5e946b016f71
+github.com/example/abc,v1.2.0,main,
+branch-not-allowed  |
+`
+	if buf.String() != expected {
+		t.Errorf("output should be:\n%s\nbut:\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,204 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// ProxyOptions options of go module proxy
+type ProxyOptions struct {
+	// GoProxy urls of go module proxy, "direct" and "off" are ignored
+	GoProxy []string
+	// GoNoProxy glob patterns of module path prefixes that should not be fetched from proxy, eg. GOPRIVATE
+	GoNoProxy string
+}
+
+// retractFindings fetches the latest go.mod of module via proxy or cloned repository,
+// and reports whether the required version is retracted or the module is deprecated
+func retractFindings(ctx context.Context, dir string, require ModRequireAnalysis, opts ProxyOptions) []Finding {
+	logger := pkgctx.GetLogger(ctx).With("module", require.Mod.Path)
+
+	file, err := latestModFileFromProxy(ctx, require.Mod.Path, opts)
+	if err != nil {
+		logger.Debugw("fetch latest go.mod from proxy error", "err", err)
+	}
+	if file == nil {
+		file, err = latestModFileFromGit(ctx, dir, require.Mod.Path)
+		if err != nil {
+			logger.Errorw("read latest go.mod from repository error", "err", err)
+			return nil
+		}
+	}
+	if file == nil {
+		return nil
+	}
+
+	return evaluateRetractions(require.Mod.Version, file)
+}
+
+// evaluateRetractions returns findings when version is retracted or the module is deprecated in the latest go.mod
+func evaluateRetractions(version string, file *modfile.File) []Finding {
+	findings := []Finding{}
+
+	for _, item := range file.Retract {
+		if semver.Compare(version, item.Low) < 0 || semver.Compare(version, item.High) > 0 {
+			continue
+		}
+
+		message := "version is retracted"
+		if item.Rationale != "" {
+			message = message + ": " + item.Rationale
+		}
+		findings = append(findings, Finding{
+			Type:     FindingRetracted,
+			Severity: SeverityError,
+			Message:  message,
+		})
+		break
+	}
+
+	if file.Module != nil && file.Module.Deprecated != "" {
+		findings = append(findings, Finding{
+			Type:     FindingDeprecated,
+			Severity: SeverityWarning,
+			Message:  "module is deprecated: " + file.Module.Deprecated,
+		})
+	}
+
+	return findings
+}
+
+// latestModFileFromProxy fetches go.mod of the latest version from proxy,
+// it returns nil if module should not be fetched from proxy
+func latestModFileFromProxy(ctx context.Context, modulePath string, opts ProxyOptions) (*modfile.File, error) {
+	if module.MatchPrefixPatterns(opts.GoNoProxy, modulePath) {
+		return nil, nil
+	}
+
+	escaped, err := module.EscapePath(modulePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for _, proxy := range opts.GoProxy {
+		if proxy == "direct" || proxy == "off" || proxy == "" {
+			continue
+		}
+		base := strings.TrimSuffix(proxy, "/") + "/" + escaped
+
+		bts, err := httpGet(ctx, base+"/@latest")
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		info := struct{ Version string }{}
+		if err = json.Unmarshal(bts, &info); err != nil {
+			lastErr = err
+			continue
+		}
+		version, err := module.EscapeVersion(info.Version)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		bts, err = httpGet(ctx, base+"/@v/"+version+".mod")
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return ParseModFile(modulePath+"@"+info.Version+"/go.mod", bts)
+	}
+
+	return nil, lastErr
+}
+
+func httpGet(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get %s error: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// latestModFileFromGit reads go.mod of the latest version from cloned repository,
+// the latest version is the highest semver tag of the major version, or the default branch if there is no tag
+func latestModFileFromGit(ctx context.Context, dir string, modulePath string) (*modfile.File, error) {
+	prefix := moduleSubDir(modulePath)
+	if prefix != "" {
+		prefix = prefix + "/"
+	}
+
+	stdout, _, err := runCmd(ctx, dir, "git", "tag", "--list", prefix+"v*")
+	if err != nil {
+		return nil, err
+	}
+
+	tags := []string{}
+	for _, item := range strings.Split(stdout, "\n") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			tags = append(tags, strings.TrimPrefix(item, prefix))
+		}
+	}
+
+	rev := "origin/HEAD"
+	if latest := latestVersion(modulePath, tags); latest != "" {
+		rev = prefix + latest
+	}
+	return readDependencyModFile(ctx, dir, modulePath, rev)
+}
+
+// latestVersion returns the highest release in versions which matches the major version of module path,
+// or the highest pre-release if there is no release
+func latestVersion(modulePath string, versions []string) string {
+	_, pathMajor, _ := module.SplitPathVersion(modulePath)
+
+	latest, latestPrerelease := "", ""
+	for _, version := range versions {
+		if !semver.IsValid(version) || semver.Build(version) != "" || module.CheckPathMajor(version, pathMajor) != nil {
+			continue
+		}
+		if semver.Prerelease(version) != "" {
+			if latestPrerelease == "" || semver.Compare(version, latestPrerelease) > 0 {
+				latestPrerelease = version
+			}
+			continue
+		}
+		if latest == "" || semver.Compare(version, latest) > 0 {
+			latest = version
+		}
+	}
+
+	if latest == "" {
+		return latestPrerelease
+	}
+	return latest
+}
+
+// moduleSubDir returns the sub directory of module in repository without the major version suffix,
+// it is empty if module is in the root, it is also the prefix of tags of the module
+func moduleSubDir(modulePath string) string {
+	host, _, repoPath := scmRepository(modulePath)
+	prefix, _, _ := module.SplitPathVersion(modulePath)
+	return strings.TrimPrefix(strings.TrimPrefix(prefix, path.Join(host, repoPath)), "/")
+}
@@ -0,0 +1,76 @@
+package pkg
+
+import (
+	"testing"
+)
+
+var latestModfileString = `
+// Deprecated: use github.com/example/demo/v2 instead.
+module github.com/example/demo
+
+go 1.19
+
+retract (
+	v1.0.5 // leaked secret in config
+	[v1.1.0, v1.1.3] // broken migration
+)
+`
+
+func TestEvaluateRetractions(t *testing.T) {
+	file, err := ParseModFile("./go.mod", []byte(latestModfileString))
+	if err != nil {
+		t.Errorf("should parse mod file correctly, but error: %s", err.Error())
+		return
+	}
+
+	cases := map[string]string{
+		"v1.0.5":                               "version is retracted: leaked secret in config",
+		"v1.1.2":                               "version is retracted: broken migration",
+		"v1.1.2-0.20230620020346-5e946b016f71": "version is retracted: broken migration",
+		"v1.1.4":                               "",
+	}
+	for version, expected := range cases {
+		findings := evaluateRetractions(version, file)
+		if len(findings) == 0 || findings[len(findings)-1].Type != FindingDeprecated {
+			t.Errorf("module should be deprecated, but: %#v", findings)
+			continue
+		}
+
+		actual := ""
+		if findings[0].Type == FindingRetracted {
+			actual = findings[0].Message
+		}
+		if actual != expected {
+			t.Errorf("retraction of %s should be %q, but: %q", version, expected, actual)
+		}
+	}
+}
+
+func TestLatestVersion(t *testing.T) {
+	versions := []string{"v1.0.0", "v1.2.0", "v1.3.0-rc.1", "v2.0.0", "invalid"}
+
+	if latest := latestVersion("github.com/example/demo", versions); latest != "v1.2.0" {
+		t.Errorf("latest version should be v1.2.0, but: %s", latest)
+	}
+	if latest := latestVersion("github.com/example/demo/v2", versions); latest != "v2.0.0" {
+		t.Errorf("latest version of v2 should be v2.0.0, but: %s", latest)
+	}
+	if latest := latestVersion("github.com/example/demo", []string{"v1.3.0-rc.1"}); latest != "v1.3.0-rc.1" {
+		t.Errorf("latest version should be the pre-release if there is no release, but: %s", latest)
+	}
+}
+
+func TestModuleSubDir(t *testing.T) {
+	cases := map[string]string{
+		"github.com/example/demo":          "",
+		"github.com/example/demo/v2":       "",
+		"github.com/example/demo/tools":    "tools",
+		"github.com/example/demo/tools/v3": "tools",
+		"gitlab.example.com/group/demo/v2": "",
+	}
+	for modulePath, expected := range cases {
+		if actual := moduleSubDir(modulePath); actual != expected {
+			t.Errorf("sub directory of %s should be %q, but: %q", modulePath, expected, actual)
+		}
+	}
+}
@@ -12,6 +12,12 @@ type githubClient struct {
 }
 
 func NewGithubClient(ctx context.Context, token string) *githubClient {
+	if token == "" {
+		return &githubClient{
+			Client: gogithub.NewClient(nil),
+		}
+	}
+
 	return &githubClient{
 		Client: gogithub.NewTokenClient(ctx, token),
 	}
@@ -70,6 +76,176 @@ func (github *githubClient) RefreshReviewComments(ctx context.Context, repoPath
 	return nil
 }
 
+func (github *githubClient) RefreshSummaryComment(ctx context.Context, repoPath string, prId int, opts RefreshSummaryCommentOptions) error {
+	logger := pkgctx.GetLogger(ctx).With("prID", prId)
+
+	owner, repo := getOwner(repoPath)
+
+	allComments, _, err := github.Issues.ListComments(ctx, owner, repo, prId, &gogithub.IssueListCommentsOptions{
+		ListOptions: gogithub.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return err
+	}
+
+	body := opts.FmtComment()
+	comment, _, err := github.Issues.CreateComment(ctx, owner, repo, prId, &gogithub.IssueComment{Body: &body})
+	if err != nil {
+		return err
+	}
+	logger.Infow("created summary comment", "id", comment.GetID())
+
+	// Delete old summary comments generated by current
+	for _, item := range allComments {
+		if !strings.HasPrefix(item.GetBody(), opts.FmtCommentBy()) {
+			continue
+		}
+		_, err = github.Issues.DeleteComment(ctx, owner, repo, item.GetID())
+		if err != nil {
+			logger.Errorw("delete old summary comment error", "id", item.GetID(), "err", err)
+		} else {
+			logger.Infow("deleted old summary comment", "id", item.GetID())
+		}
+	}
+
+	return nil
+}
+
+func (github *githubClient) GetCommitSHA(ctx context.Context, repoPath string, ref string) (string, error) {
+	owner, repo := getOwner(repoPath)
+
+	sha, _, err := github.Repositories.GetCommitSHA1(ctx, owner, repo, ref, "")
+	if err != nil {
+		return "", err
+	}
+	return sha, nil
+}
+
+func (github *githubClient) ListPullRequestsWithCommit(ctx context.Context, repoPath string, sha string) ([]PullRequest, error) {
+	owner, repo := getOwner(repoPath)
+
+	prs := []*gogithub.PullRequest{}
+	opts := &gogithub.PullRequestListOptions{State: "all", ListOptions: gogithub.ListOptions{PerPage: 100}}
+	for {
+		items, resp, err := github.PullRequests.ListPullRequestsWithCommit(ctx, owner, repo, sha, opts)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, items...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	res := []PullRequest{}
+	for _, item := range prs {
+		pr := PullRequest{
+			ID:         item.GetNumber(),
+			Title:      item.GetTitle(),
+			URL:        item.GetHTMLURL(),
+			HeadBranch: item.GetHead().GetRef(),
+			State:      PullRequestOpen,
+		}
+		if item.MergedAt != nil {
+			pr.State = PullRequestMerged
+		} else if item.GetState() == "closed" {
+			pr.State = PullRequestClosed
+		}
+
+		reviews, err := github.listReviews(ctx, owner, repo, pr.ID)
+		if err != nil {
+			pkgctx.GetLogger(ctx).Warnw("list reviews of pull request error", "repo", repoPath, "prID", pr.ID, "err", err)
+			pr.ApprovalsUnknown = true
+			res = append(res, pr)
+			continue
+		}
+		pr.Approvals = countApprovals(reviews)
+
+		res = append(res, pr)
+	}
+	return res, nil
+}
+
+// listReviews lists all pages of reviews of the pull request
+func (github *githubClient) listReviews(ctx context.Context, owner string, repo string, id int) ([]*gogithub.PullRequestReview, error) {
+	reviews := []*gogithub.PullRequestReview{}
+	opts := &gogithub.ListOptions{PerPage: 100}
+	for {
+		items, resp, err := github.PullRequests.ListReviews(ctx, owner, repo, id, opts)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, items...)
+		if resp.NextPage == 0 {
+			return reviews, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// countApprovals counts reviewers whose latest review approves the pull request, reviews are in chronological order,
+// comments do not change the state of previous review, but a later review that requests changes or is dismissed does
+func countApprovals(reviews []*gogithub.PullRequestReview) int {
+	latest := map[string]string{}
+	for _, review := range reviews {
+		state := review.GetState()
+		if state == "COMMENTED" || state == "PENDING" {
+			continue
+		}
+		latest[review.GetUser().GetLogin()] = state
+	}
+
+	approvals := 0
+	for _, state := range latest {
+		if state == "APPROVED" {
+			approvals++
+		}
+	}
+	return approvals
+}
+
+func (github *githubClient) ListCommitStatuses(ctx context.Context, repoPath string, sha string) ([]CommitStatus, error) {
+	owner, repo := getOwner(repoPath)
+
+	combined, _, err := github.Repositories.GetCombinedStatus(ctx, owner, repo, sha, &gogithub.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, err
+	}
+
+	res := []CommitStatus{}
+	for _, item := range combined.Statuses {
+		state := CommitStatusPending
+		switch item.GetState() {
+		case "success":
+			state = CommitStatusSuccess
+		case "failure", "error":
+			state = CommitStatusFailure
+		}
+		res = append(res, CommitStatus{Context: item.GetContext(), State: state, URL: item.GetTargetURL()})
+	}
+
+	checks, _, err := github.Checks.ListCheckRunsForRef(ctx, owner, repo, sha, &gogithub.ListCheckRunsOptions{
+		ListOptions: gogithub.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range checks.CheckRuns {
+		state := CommitStatusPending
+		if item.GetStatus() == "completed" {
+			state = CommitStatusFailure
+			switch item.GetConclusion() {
+			case "success", "neutral", "skipped":
+				state = CommitStatusSuccess
+			}
+		}
+		res = append(res, CommitStatus{Context: item.GetName(), State: state, URL: item.GetHTMLURL()})
+	}
+
+	return res, nil
+}
+
 func getOwner(repoPath string) (owner string, repo string) {
 	segments := strings.Split(repoPath, "/")
 	return segments[0], strings.TrimPrefix(repoPath, segments[0]+"/")
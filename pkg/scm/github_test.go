@@ -3,9 +3,9 @@ package scm
 import (
 	"context"
 	"fmt"
-	pkgctx "github.com/chengjingtao/gomod-version-lint/pkg/context"
 	gogithub "github.com/google/go-github/v53/github"
 	"go.uber.org/zap"
+	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
 	"os"
 	"testing"
 )
@@ -58,3 +58,23 @@ func TestGithubClient_RefreshReviewComments(t *testing.T) {
 		t.Errorf("error to refreshh comment")
 	}
 }
+
+func TestCountApprovals(t *testing.T) {
+	review := func(user string, state string) *gogithub.PullRequestReview {
+		return &gogithub.PullRequestReview{User: &gogithub.User{Login: gogithub.String(user)}, State: gogithub.String(state)}
+	}
+	reviews := []*gogithub.PullRequestReview{
+		review("alice", "APPROVED"),
+		review("alice", "COMMENTED"),
+		review("bob", "APPROVED"),
+		review("bob", "CHANGES_REQUESTED"),
+		review("carol", "APPROVED"),
+		review("carol", "DISMISSED"),
+		review("dave", "CHANGES_REQUESTED"),
+		review("dave", "APPROVED"),
+	}
+
+	if approvals := countApprovals(reviews); approvals != 2 {
+		t.Errorf("only latest review of each reviewer should be counted, expected 2 approvals, but: %d", approvals)
+	}
+}
@@ -87,3 +87,102 @@ func (gitlab *gitlabClient) RefreshReviewComments(ctx context.Context, repoPath
 
 	return nil
 }
+
+func (gitlab *gitlabClient) RefreshSummaryComment(ctx context.Context, repoPath string, prId int, opts RefreshSummaryCommentOptions) error {
+	logger := pkgctx.GetLogger(ctx).With("prID", prId)
+
+	allNotes, _, err := gitlab.Notes.ListMergeRequestNotes(repoPath, prId, &gogitlab.ListMergeRequestNotesOptions{
+		ListOptions: gogitlab.ListOptions{PerPage: 100},
+	}, gogitlab.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	body := opts.FmtComment()
+	note, _, err := gitlab.Notes.CreateMergeRequestNote(repoPath, prId, &gogitlab.CreateMergeRequestNoteOptions{Body: &body}, gogitlab.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	logger.Infow("created summary comment", "id", note.ID)
+
+	// Delete old summary comments generated by current
+	for _, item := range allNotes {
+		if !strings.HasPrefix(item.Body, opts.FmtCommentBy()) {
+			continue
+		}
+		_, err = gitlab.Notes.DeleteMergeRequestNote(repoPath, prId, item.ID, gogitlab.WithContext(ctx))
+		if err != nil {
+			logger.Errorw("delete old summary comment error", "id", item.ID, "err", err)
+		} else {
+			logger.Infow("deleted old summary comment", "id", item.ID)
+		}
+	}
+
+	return nil
+}
+
+func (gitlab *gitlabClient) GetCommitSHA(ctx context.Context, repoPath string, ref string) (string, error) {
+	commit, _, err := gitlab.Commits.GetCommit(repoPath, ref, gogitlab.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	return commit.ID, nil
+}
+
+func (gitlab *gitlabClient) ListPullRequestsWithCommit(ctx context.Context, repoPath string, sha string) ([]PullRequest, error) {
+	mrs, _, err := gitlab.Commits.ListMergeRequestsByCommit(repoPath, sha, gogitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	res := []PullRequest{}
+	for _, item := range mrs {
+		pr := PullRequest{
+			ID:         item.IID,
+			Title:      item.Title,
+			URL:        item.WebURL,
+			HeadBranch: item.SourceBranch,
+			State:      PullRequestOpen,
+		}
+		switch item.State {
+		case "merged":
+			pr.State = PullRequestMerged
+		case "closed", "locked":
+			pr.State = PullRequestClosed
+		}
+
+		approvals, _, err := gitlab.MergeRequestApprovals.GetConfiguration(repoPath, item.IID, gogitlab.WithContext(ctx))
+		if err != nil {
+			pkgctx.GetLogger(ctx).Warnw("get approvals of merge request error", "repo", repoPath, "prID", pr.ID, "err", err)
+			pr.ApprovalsUnknown = true
+			res = append(res, pr)
+			continue
+		}
+		pr.Approvals = len(approvals.ApprovedBy)
+
+		res = append(res, pr)
+	}
+	return res, nil
+}
+
+func (gitlab *gitlabClient) ListCommitStatuses(ctx context.Context, repoPath string, sha string) ([]CommitStatus, error) {
+	statuses, _, err := gitlab.Commits.GetCommitStatuses(repoPath, sha, &gogitlab.GetCommitStatusesOptions{
+		ListOptions: gogitlab.ListOptions{PerPage: 100},
+	}, gogitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	res := []CommitStatus{}
+	for _, item := range statuses {
+		state := CommitStatusPending
+		switch item.Status {
+		case "success", "skipped":
+			state = CommitStatusSuccess
+		case "failed", "canceled":
+			state = CommitStatusFailure
+		}
+		res = append(res, CommitStatus{Context: item.Name, State: state, URL: item.TargetURL})
+	}
+	return res, nil
+}
@@ -2,9 +2,9 @@ package scm
 
 import (
 	"context"
-	pkgctx "github.com/chengjingtao/gomod-version-lint/pkg/context"
 	gogitlab "github.com/xanzy/go-gitlab"
 	"go.uber.org/zap"
+	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
 	"os"
 	"testing"
 )
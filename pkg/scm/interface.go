@@ -3,6 +3,7 @@ package scm
 import (
 	"context"
 	"fmt"
+	"strings"
 )
 
 type RefreshReviewCommentOptions struct {
@@ -20,14 +21,69 @@ func (opts ReviewComment) FmtComment(commentBy string) string {
 	return fmt.Sprintf("<!-- %s -->\n%s", commentBy, opts.Body)
 }
 
+// RefreshSummaryCommentOptions options of the summary comment of pull request, eg. markdown report
+type RefreshSummaryCommentOptions struct {
+	CommentBy string
+	Body      string
+}
+
+func (opts RefreshSummaryCommentOptions) FmtCommentBy() string {
+	return fmt.Sprintf("<!-- %s -->", opts.CommentBy)
+}
+
+func (opts RefreshSummaryCommentOptions) FmtComment() string {
+	return fmt.Sprintf("%s\n%s", opts.FmtCommentBy(), opts.Body)
+}
+
 type ReviewComment struct {
 	Body string
 	Path string
 	Line int
 }
 
+// PullRequest pull request on github or merge request on gitlab
+type PullRequest struct {
+	ID         int    `json:"id" yaml:"id"`
+	Title      string `json:"title" yaml:"title"`
+	URL        string `json:"url" yaml:"url"`
+	HeadBranch string `json:"headBranch" yaml:"headBranch"`
+	// State open, merged or closed
+	State     string `json:"state" yaml:"state"`
+	Approvals int    `json:"approvals" yaml:"approvals"`
+	// ApprovalsUnknown approvals could not be listed, eg. token has no permission to read reviews
+	ApprovalsUnknown bool `json:"approvalsUnknown,omitempty" yaml:"approvalsUnknown,omitempty"`
+}
+
+const (
+	PullRequestOpen   = "open"
+	PullRequestMerged = "merged"
+	PullRequestClosed = "closed"
+)
+
+// CommitStatus status of a ci context on commit, it could be a commit status or a check run
+type CommitStatus struct {
+	Context string `json:"context" yaml:"context"`
+	// State success, pending or failure
+	State string `json:"state" yaml:"state"`
+	URL   string `json:"url" yaml:"url"`
+}
+
+const (
+	CommitStatusSuccess = "success"
+	CommitStatusPending = "pending"
+	CommitStatusFailure = "failure"
+)
+
 type Client interface {
 	RefreshReviewComments(ctx context.Context, repoPath string, prId int, opts RefreshReviewCommentOptions) error
+	// RefreshSummaryComment creates the summary comment of pull request and deletes old ones created by opts.CommentBy
+	RefreshSummaryComment(ctx context.Context, repoPath string, prId int, opts RefreshSummaryCommentOptions) error
+	// GetCommitSHA returns the full sha of ref, ref could be an abbreviated sha, branch or tag
+	GetCommitSHA(ctx context.Context, repoPath string, ref string) (string, error)
+	// ListPullRequestsWithCommit lists pull requests whose head branch contains the commit
+	ListPullRequestsWithCommit(ctx context.Context, repoPath string, sha string) ([]PullRequest, error)
+	// ListCommitStatuses lists ci statuses of the commit
+	ListCommitStatuses(ctx context.Context, repoPath string, sha string) ([]CommitStatus, error)
 }
 
 type scmClient struct {
@@ -53,3 +109,15 @@ func NewScmClient(ctx context.Context, t string, baseUrl string, token string) (
 
 	return nil, fmt.Errorf("unknown type: %s", t)
 }
+
+// DetectServerType detects git server type by host, it returns empty if the type is unknown
+func DetectServerType(host string) string {
+	host = strings.ToLower(host)
+	if host == "github.com" {
+		return "github"
+	}
+	if strings.Contains(host, "gitlab") {
+		return "gitlab"
+	}
+	return ""
+}
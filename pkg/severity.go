@@ -0,0 +1,56 @@
+package pkg
+
+import (
+	"fmt"
+)
+
+// Severity severity of finding
+type Severity string
+
+const (
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+var severityRanks = map[Severity]int{
+	SeverityInfo:    1,
+	SeverityWarning: 2,
+	SeverityError:   3,
+}
+
+// ParseSeverity parses severity from string, it should be one of info, warning and error
+func ParseSeverity(str string) (Severity, error) {
+	severity := Severity(str)
+	if _, ok := severityRanks[severity]; !ok {
+		return "", fmt.Errorf("unknown severity '%s', it should be one of info, warning and error", str)
+	}
+	return severity, nil
+}
+
+// AtLeast returns true if severity is equal or higher than threshold
+func (severity Severity) AtLeast(threshold Severity) bool {
+	return severityRanks[severity] >= severityRanks[threshold]
+}
+
+// IsViolation returns true if the finding is not suppressed and its severity is equal or higher than threshold,
+// nothing is violation if threshold is not a known severity, eg. none
+func (finding Finding) IsViolation(threshold Severity) bool {
+	if _, ok := severityRanks[threshold]; !ok {
+		return false
+	}
+	return finding.SuppressedBy == "" && finding.Severity.AtLeast(threshold)
+}
+
+// CountViolations counts findings which are not suppressed and severity are equal or higher than threshold
+func CountViolations(mods []ModRequireAnalysis, threshold Severity) int {
+	count := 0
+	for _, item := range mods {
+		for _, finding := range item.Findings {
+			if finding.IsViolation(threshold) {
+				count++
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,38 @@
+package pkg
+
+import (
+	"testing"
+)
+
+func TestCountViolations(t *testing.T) {
+	mods := []ModRequireAnalysis{
+		{
+			Findings: []Finding{
+				{Type: FindingBranchNotAllowed, Severity: SeverityError},
+				{Type: FindingNewerRelease, Severity: SeverityInfo},
+			},
+		},
+		{
+			Findings: []Finding{
+				{Type: FindingBranchNotFound, Severity: SeverityError, SuppressedBy: BaselineSuppression},
+				{Type: FindingDirectiveUnused, Severity: SeverityWarning},
+			},
+		},
+	}
+
+	cases := map[Severity]int{
+		SeverityError:   1,
+		SeverityWarning: 2,
+		SeverityInfo:    3,
+	}
+	for threshold, expected := range cases {
+		actual := CountViolations(mods, threshold)
+		if actual != expected {
+			t.Errorf("violations with severity %s or higher should be %d, but: %d", threshold, expected, actual)
+		}
+	}
+
+	if _, err := ParseSeverity("fatal"); err == nil {
+		t.Errorf("parse unknown severity should return error")
+	}
+}
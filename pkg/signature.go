@@ -0,0 +1,129 @@
+package pkg
+
+import (
+	"context"
+	"golang.org/x/mod/module"
+	pkgctx "gomod.alauda.cn/gomod-version-lint/pkg/context"
+	"regexp"
+	"strings"
+)
+
+// SignatureOptions options of signature verification of the pinned commit or tag
+type SignatureOptions struct {
+	// Verify enables signature verification
+	Verify bool
+	// GPGHome gpg home directory that contains the keyring, it is optional
+	GPGHome string
+	// AllowedSignersFile absolute path of allowed signers file for ssh signatures, it is optional
+	AllowedSignersFile string
+}
+
+var (
+	// eg. Good "git" signature for someone@example.com with ED25519 key SHA256:xxx
+	sshSignerRegex = regexp.MustCompile(`Good "git" signature for (\S+) with`)
+	// eg. gpg: Good signature from "someone <someone@example.com>" [ultimate]
+	gpgSignerRegex = regexp.MustCompile(`Good signature from "([^"]+)"`)
+)
+
+// signatureFindings verifies signature of the annotated tag or the commit of rev,
+// it returns identity of the signer if the signature is trusted
+func signatureFindings(ctx context.Context, dir string, require ModRequireAnalysis, rev string, opts SignatureOptions) (string, []Finding) {
+	logger := pkgctx.GetLogger(ctx).With("module", require.Mod.Path, "rev", rev)
+
+	if !opts.Verify {
+		return "", nil
+	}
+
+	objects := []string{rev + "^{commit}"}
+	if !module.IsPseudoVersion(require.Mod.Version) {
+		objectType, _, err := runCmd(ctx, dir, "git", "cat-file", "-t", rev)
+		if err == nil && strings.TrimSpace(objectType) == "tag" {
+			// signed annotated tag is preferred
+			objects = append([]string{rev}, objects...)
+		}
+	}
+
+	untrusted := ""
+	for _, object := range objects {
+		if !hasSignature(ctx, dir, object) {
+			continue
+		}
+
+		signer, message := verifySignature(ctx, dir, object, opts)
+		if signer != "" {
+			return signer, nil
+		}
+		logger.Infow("signature is not trusted", "object", object, "message", message)
+		untrusted = message
+	}
+
+	if untrusted != "" {
+		return "", []Finding{
+			{
+				Type:     FindingUntrustedSignature,
+				Severity: SeverityError,
+				Message:  "signature is not trusted: " + untrusted,
+			},
+		}
+	}
+
+	return "", []Finding{
+		{
+			Type:     FindingUnsigned,
+			Severity: SeverityError,
+			Message:  "neither the commit nor the tag is signed",
+		},
+	}
+}
+
+// hasSignature returns true if the commit or tag object contains signature
+func hasSignature(ctx context.Context, dir string, object string) bool {
+	stdout, _, err := runCmd(ctx, dir, "git", "cat-file", "-p", object)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(stdout, "\ngpgsig ") || strings.Contains(stdout, "-----BEGIN PGP SIGNATURE-----") ||
+		strings.Contains(stdout, "-----BEGIN SSH SIGNATURE-----")
+}
+
+// verifySignature verifies signature of the object, it returns the signer if signature is trusted,
+// or returns the reason if it is not trusted
+func verifySignature(ctx context.Context, dir string, object string, opts SignatureOptions) (signer string, message string) {
+	env := []string{}
+	if opts.GPGHome != "" {
+		env = append(env, "GNUPGHOME="+opts.GPGHome)
+	}
+	args := []string{}
+	if opts.AllowedSignersFile != "" {
+		args = append(args, "-c", "gpg.ssh.allowedSignersFile="+opts.AllowedSignersFile)
+	}
+
+	verify := "verify-commit"
+	if !strings.HasSuffix(object, "^{commit}") {
+		verify = "verify-tag"
+	}
+	args = append(args, verify, "-v", object)
+
+	_, stderr, err := runCmdWith(ctx, dir, nil, env, "git", args...)
+	if err != nil {
+		return "", lastLine(stderr)
+	}
+
+	return parseSigner(stderr), ""
+}
+
+// parseSigner parses identity of signer from output of verify-commit or verify-tag
+func parseSigner(stderr string) string {
+	if matches := sshSignerRegex.FindStringSubmatch(stderr); len(matches) == 2 {
+		return matches[1]
+	}
+	if matches := gpgSignerRegex.FindStringSubmatch(stderr); len(matches) == 2 {
+		return matches[1]
+	}
+	return "unknown"
+}
+
+func lastLine(str string) string {
+	lines := strings.Split(strings.TrimSpace(str), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,20 @@
+package pkg
+
+import (
+	"testing"
+)
+
+func TestParseSigner(t *testing.T) {
+	cases := map[string]string{
+		`Good "git" signature for someone@example.com with ED25519 key SHA256:nCZLx2azNLZfThuEIAJ1dX5YgcGy`:                       "someone@example.com",
+		"gpg: Signature made Mon Jun 26 10:00:00 2023 CST\ngpg: Good signature from \"someone <someone@example.com>\" [ultimate]": "someone <someone@example.com>",
+		"": "unknown",
+	}
+
+	for stderr, expected := range cases {
+		actual := parseSigner(stderr)
+		if actual != expected {
+			t.Errorf("signer of %q should be %q, but: %q", stderr, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package pkg
+
+// CommitURL returns web url of the commit in repository of the module, it returns empty if the git server type is unknown
+func CommitURL(modulePath string, commit string) string {
+	return webURL(modulePath, "commit", commit)
+}
+
+// BranchURL returns web url of the branch in repository of the module, it returns empty if the git server type is unknown
+func BranchURL(modulePath string, branch string) string {
+	return webURL(modulePath, "tree", branch)
+}
+
+func webURL(modulePath string, kind string, ref string) string {
+	host, serverType, repoPath := scmRepository(modulePath)
+	if ref == "" || repoPath == "" {
+		return ""
+	}
+
+	switch serverType {
+	case "github":
+		return "https://" + host + "/" + repoPath + "/" + kind + "/" + ref
+	case "gitlab":
+		return "https://" + host + "/" + repoPath + "/-/" + kind + "/" + ref
+	}
+	return ""
+}
@@ -0,0 +1,21 @@
+package pkg
+
+import "testing"
+
+func TestWebURL(t *testing.T) {
+	cases := []struct {
+		actual   string
+		expected string
+	}{
+		{CommitURL("github.com/example/demo/v2", "5e946b016f71"), "https://github.com/example/demo/commit/5e946b016f71"},
+		{BranchURL("gitlab.example.com/group/demo", "main"), "https://gitlab.example.com/group/demo/-/tree/main"},
+		{CommitURL("gomod.example.com/demo/abc", "5e946b016f71"), ""},
+		{CommitURL("github.com/example/demo", ""), ""},
+	}
+
+	for i, c := range cases {
+		if c.actual != c.expected {
+			t.Errorf("case %d: url should be %q, but: %q", i, c.expected, c.actual)
+		}
+	}
+}